@@ -2,9 +2,21 @@ package main
 
 import (
 	"log"
+	"os"
+	"slices"
 
+	"github.com/khevse/codegen/internal/command/config_runner"
+	"github.com/khevse/codegen/internal/command/directive_runner"
+	"github.com/khevse/codegen/internal/command/func_adapter"
 	"github.com/khevse/codegen/internal/command/interface_creator"
+	"github.com/khevse/codegen/internal/command/log_decorator"
+	"github.com/khevse/codegen/internal/command/mock_creator"
 	"github.com/khevse/codegen/internal/command/object_test_wrapper"
+	"github.com/khevse/codegen/internal/command/package_inspector"
+	"github.com/khevse/codegen/internal/command/plugin_runner"
+	"github.com/khevse/codegen/internal/command/resilience_decorator"
+	"github.com/khevse/codegen/internal/command/telemetry_decorator"
+	"github.com/khevse/codegen/internal/command/template_dumper"
 	"github.com/khevse/codegen/internal/pkg/command"
 	"github.com/spf13/cobra"
 )
@@ -15,14 +27,44 @@ func main() {
 		Args: cobra.ArbitraryArgs,
 	}
 
-	for _, cmd := range []command.Command{
+	commands := []command.Command{
 		interface_creator.New(),
 		object_test_wrapper.New(),
-	} {
+		mock_creator.New(),
+		log_decorator.New(),
+		telemetry_decorator.New(),
+		resilience_decorator.New(),
+		func_adapter.New(),
+	}
+	newRunners := func(commands ...command.Command) []command.Command {
+		configRunner := config_runner.New(commands...)
+		return []command.Command{
+			configRunner,
+			// the directives can run the config: //codegen:generate run --config=codegen.yaml
+			directive_runner.New(slices.Concat(commands, []command.Command{configRunner})...),
+			template_dumper.New(commands...),
+			package_inspector.New(),
+		}
+	}
+
+	// the plugins do not replace the built-in commands, they are executed
+	// by the config and the directives as the built-in commands
+	plugins := plugin_runner.Discover(os.Getenv("PATH"), slices.Concat(commands, newRunners()))
+	commands = slices.Concat(commands, plugins)
+	commands = slices.Concat(commands, newRunners(commands...))
+
+	for _, cmd := range commands {
 		childCmd := &cobra.Command{
 			Use:   cmd.Name(),
 			Short: cmd.ShortName(),
-			RunE: func(*cobra.Command, []string) error {
+			RunE: func(childCmd *cobra.Command, args []string) error {
+				// the flags are valid, the usage is not helpful for the execution errors
+				childCmd.SilenceUsage = true
+
+				if setter, ok := cmd.(command.ArgsSetter); ok {
+					setter.SetArgs(args)
+				}
+
 				return cmd.Execute()
 			},
 		}
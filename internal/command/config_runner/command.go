@@ -0,0 +1,154 @@
+package config_runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/command"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+	"github.com/samber/lo"
+)
+
+const defaultConfigFile = "codegen.yaml"
+
+type commandArgs struct {
+	configFile string
+	check      bool
+	dryRun     bool
+	output     string
+}
+
+type Command struct {
+	args     commandArgs
+	commands []command.Command
+}
+
+// New returns the command executing the jobs of the commands from the config file.
+func New(commands ...command.Command) *Command {
+	return &Command{
+		commands: commands,
+	}
+}
+
+func (c *Command) Name() string {
+	return "run"
+}
+
+func (c *Command) ShortName() string {
+	return "r"
+}
+
+func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
+	const (
+		flagConfigFile = "config"
+	)
+
+	flagSetter.Flags().StringVarP(
+		&c.args.configFile,
+		flagConfigFile,
+		"c",
+		defaultConfigFile,
+		"config file with the list of jobs",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.check,
+		command.CheckFlag,
+		"",
+		false,
+		"check that the files generated by all jobs are up to date without writing them",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.dryRun,
+		command.DryRunFlag,
+		"",
+		false,
+		"list the files which would be written or changed by all jobs without writing them",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.output,
+		command.OutputFlag,
+		"",
+		"",
+		"print the code generated by all jobs to stdout instead of writing the files, the only supported value is -",
+	)
+
+	return nil
+}
+
+func (c *Command) Execute() error {
+	cfg, err := readConfig(c.args.configFile)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	return c.run(cfg)
+}
+
+func (c *Command) run(cfg config) (resErr error) {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working dir: %w", err)
+	}
+	defer func() {
+		if err := os.Chdir(workDir); err != nil {
+			resErr = errors.Join(resErr, fmt.Errorf("restore working dir: %w", err))
+		}
+	}()
+
+	cache := astpkg.NewPackageCache()
+
+	// the check mode runs all jobs to report all outdated files
+	outdated := make([]error, 0)
+
+	for i, item := range cfg.Jobs {
+		err := c.runJob(cache, cfg.Dir, item)
+		if err == nil {
+			continue
+		}
+
+		err = fmt.Errorf("job %d(%s): %w", i, item, err)
+		if c.args.check && errors.Is(err, filepkg.ErrOutdated) {
+			outdated = append(outdated, err)
+			continue
+		}
+
+		return err
+	}
+
+	return errors.Join(outdated...)
+}
+
+func (c *Command) runJob(cache *astpkg.PackageCache, dir string, item job) error {
+	cmd, ok := command.Find(c.commands, item.Command)
+	if !ok {
+		return fmt.Errorf("unknown command: %s", item.Command)
+	}
+
+	// the relative paths of the job are resolved from the dir of the config file,
+	// the previous job could change the working dir
+	if err := os.Chdir(dir); err != nil {
+		return fmt.Errorf("change working dir: %w", err)
+	}
+
+	return command.RunJob(command.RunJobParams{
+		Command: cmd,
+		SetFlags: func(flagSet *command.FlagSet) error {
+			names := lo.Keys(item.Args)
+			slices.Sort(names)
+			for _, name := range names {
+				if err := flagSet.Set(name, string(item.Args[name])); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		Check:  c.args.check,
+		DryRun: c.args.dryRun,
+		Output: c.args.output,
+		Cache:  cache,
+	})
+}
@@ -0,0 +1,309 @@
+package config_runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/command"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCommand struct {
+	name         string
+	required     bool
+	supportCheck bool
+	supportModes bool
+	err          error
+
+	value    string
+	enabled  bool
+	check    bool
+	dryRun   bool
+	output   string
+	cache    *astpkg.PackageCache
+	executed []string
+	dirs     []string
+	caches   []*astpkg.PackageCache
+}
+
+func (c *fakeCommand) Name() string {
+	return c.name
+}
+
+func (c *fakeCommand) ShortName() string {
+	return c.name[:1]
+}
+
+func (c *fakeCommand) InitFlags(flagSetter command.FlagSetter) error {
+	flagSetter.Flags().StringVarP(&c.value, "value", "v", "", "")
+	flagSetter.Flags().BoolVarP(&c.enabled, "enabled", "", false, "")
+	if c.supportCheck {
+		flagSetter.Flags().BoolVarP(&c.check, "check", "", false, "")
+	}
+	if c.supportModes {
+		flagSetter.Flags().BoolVarP(&c.dryRun, "dry-run", "", false, "")
+		flagSetter.Flags().StringVarP(&c.output, "output", "", "", "")
+	}
+
+	if c.required {
+		return flagSetter.MarkFlagRequired("value")
+	}
+
+	return nil
+}
+
+func (c *fakeCommand) SetPackageCache(cache *astpkg.PackageCache) {
+	c.cache = cache
+}
+
+func (c *fakeCommand) Execute() error {
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	c.executed = append(c.executed, c.value)
+	c.caches = append(c.caches, c.cache)
+	c.dirs = append(c.dirs, dir)
+
+	if c.enabled {
+		c.executed = append(c.executed, "enabled")
+	}
+	if c.dryRun {
+		c.executed = append(c.executed, "dry-run")
+	}
+	if c.output != "" {
+		c.executed = append(c.executed, "output="+c.output)
+	}
+
+	if c.check && c.err == nil {
+		return fmt.Errorf("%w: %s", filepkg.ErrOutdated, c.value)
+	}
+
+	return c.err
+}
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		cfg, err := parseConfig([]byte(`
+jobs:
+  - name: interfaces
+    command: interface
+    args:
+      type:
+        - github.com/package.Type1=IType1
+        - github.com/package.Type2
+      target-dir: ./pkg
+      promoted: true
+  - command: mock
+`))
+		require.NoError(t, err)
+		require.Equal(t,
+			config{
+				Jobs: []job{
+					{
+						Name:    "interfaces",
+						Command: "interface",
+						Args: map[string]jobArg{
+							"type":       "github.com/package.Type1=IType1,github.com/package.Type2",
+							"target-dir": "./pkg",
+							"promoted":   "true",
+						},
+					},
+					{
+						Command: "mock",
+					},
+				},
+			},
+			cfg,
+		)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := parseConfig([]byte(`
+jobs:
+  - command: mock
+    flags: {}
+`))
+		require.EqualError(t, err, "decode: yaml: unmarshal errors:\n  line 4: field flags not found in type config_runner.job")
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		_, err := parseConfig([]byte(`
+jobs:
+  - command: mock
+    args:
+      type: {name: value}
+`))
+		require.EqualError(t, err, "decode: line 5: argument is not scalar or list")
+	})
+
+	t.Run("command is not set", func(t *testing.T) {
+		_, err := parseConfig([]byte(`
+jobs:
+  - name: mocks
+`))
+		require.EqualError(t, err, "job 0(mocks): command is not set")
+	})
+}
+
+func TestReadConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "codegen.yaml")
+	require.NoError(t, os.WriteFile(filePath, []byte("jobs:\n  - command: mock\n"), 0o644))
+
+	cfg, err := readConfig(filePath)
+	require.NoError(t, err)
+	require.Equal(t, config{Dir: dir, Jobs: []job{{Command: "mock"}}}, cfg)
+
+	_, err = readConfig(filepath.Join(dir, "unknown.yaml"))
+	require.ErrorContains(t, err, "read file("+filepath.Join(dir, "unknown.yaml")+"):")
+}
+
+func TestRun(t *testing.T) {
+	// the jobs change the working dir, the test is not parallel
+	dir, err := filepath.Abs("..")
+	require.NoError(t, err)
+	workDir, err := os.Getwd()
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		cmd1 := &fakeCommand{name: "first", required: true}
+		cmd2 := &fakeCommand{name: "second"}
+
+		err := New(cmd1, cmd2).run(config{
+			Dir: dir,
+			Jobs: []job{
+				{Command: "first", Args: map[string]jobArg{"value": "1", "enabled": "true"}},
+				{Command: "s", Args: map[string]jobArg{"value": "2"}},
+				{Command: "first", Args: map[string]jobArg{"value": "3"}},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "enabled", "3"}, cmd1.executed)
+		require.Equal(t, []string{"2"}, cmd2.executed)
+		require.Equal(t, []string{dir, dir}, cmd1.dirs)
+
+		currentDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.Equal(t, workDir, currentDir)
+
+		// all jobs share the same cache, it is reset after the job
+		require.NotNil(t, cmd1.caches[0])
+		require.Same(t, cmd1.caches[0], cmd1.caches[1])
+		require.Same(t, cmd1.caches[0], cmd2.caches[0])
+		require.Nil(t, cmd1.cache)
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		err := New(&fakeCommand{name: "first"}).run(config{
+			Dir:  dir,
+			Jobs: []job{{Name: "job", Command: "unknown"}},
+		})
+		require.EqualError(t, err, "job 0(job): unknown command: unknown")
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		err := New(&fakeCommand{name: "first"}).run(config{
+			Dir:  dir,
+			Jobs: []job{{Command: "first", Args: map[string]jobArg{"unknown": "1"}}},
+		})
+		require.EqualError(t, err, "job 0(first): unknown flag: unknown")
+	})
+
+	t.Run("required flag", func(t *testing.T) {
+		err := New(&fakeCommand{name: "first", required: true}).run(config{
+			Dir:  dir,
+			Jobs: []job{{Command: "first"}},
+		})
+		require.EqualError(t, err, "job 0(first): required flags are not set: [value]")
+	})
+
+	t.Run("execute error", func(t *testing.T) {
+		cmd := &fakeCommand{name: "first", err: errors.New("failed")}
+
+		err := New(cmd).run(config{
+			Dir:  dir,
+			Jobs: []job{{Command: "first"}, {Command: "first"}},
+		})
+		require.EqualError(t, err, "job 0(first): execute(first): failed")
+		require.Equal(t, []string{""}, cmd.executed)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		cmd := &fakeCommand{name: "first", supportCheck: true}
+
+		c := New(cmd)
+		c.args.check = true
+
+		err := c.run(config{
+			Dir: dir,
+			Jobs: []job{
+				{Command: "first", Args: map[string]jobArg{"value": "1"}},
+				{Command: "first", Args: map[string]jobArg{"value": "2"}},
+			},
+		})
+		require.ErrorIs(t, err, filepkg.ErrOutdated)
+		require.EqualError(t, err, "job 0(first): execute(first): generated files are out of date: 1\njob 1(first): execute(first): generated files are out of date: 2")
+		require.Equal(t, []string{"1", "2"}, cmd.executed)
+	})
+
+	t.Run("check is not supported", func(t *testing.T) {
+		c := New(&fakeCommand{name: "first"})
+		c.args.check = true
+
+		err := c.run(config{
+			Dir:  dir,
+			Jobs: []job{{Command: "first"}},
+		})
+		require.EqualError(t, err, "job 0(first): command does not support the check mode")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		cmd := &fakeCommand{name: "first", supportModes: true}
+
+		c := New(cmd)
+		c.args.dryRun = true
+
+		err := c.run(config{
+			Dir:  dir,
+			Jobs: []job{{Command: "first", Args: map[string]jobArg{"value": "1"}}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "dry-run"}, cmd.executed)
+	})
+
+	t.Run("output", func(t *testing.T) {
+		cmd := &fakeCommand{name: "first", supportModes: true}
+
+		c := New(cmd)
+		c.args.output = "-"
+
+		err := c.run(config{
+			Dir:  dir,
+			Jobs: []job{{Command: "first", Args: map[string]jobArg{"value": "1"}}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "output=-"}, cmd.executed)
+	})
+
+	t.Run("dry run is not supported", func(t *testing.T) {
+		c := New(&fakeCommand{name: "first"})
+		c.args.dryRun = true
+
+		err := c.run(config{
+			Dir:  dir,
+			Jobs: []job{{Command: "first"}},
+		})
+		require.EqualError(t, err, "job 0(first): command does not support the dry run mode")
+	})
+}
@@ -0,0 +1,95 @@
+package config_runner
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type config struct {
+	// Dir is the absolute path of the dir of the config file, the jobs are executed from it
+	Dir  string `yaml:"-"`
+	Jobs []job  `yaml:"jobs"`
+}
+
+type job struct {
+	// Name is used in the errors, optional
+	Name string `yaml:"name"`
+	// Command name or short name of the command
+	Command string `yaml:"command"`
+	// Args flags values of the command: the lists are joined by comma
+	Args map[string]jobArg `yaml:"args"`
+}
+
+func (j job) String() string {
+	if j.Name != "" {
+		return j.Name
+	}
+
+	return j.Command
+}
+
+type jobArg string
+
+func (a *jobArg) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*a = jobArg(node.Value)
+	case yaml.SequenceNode:
+		list := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: list item is not scalar", item.Line)
+			}
+
+			list = append(list, item.Value)
+		}
+
+		*a = jobArg(strings.Join(list, ","))
+	default:
+		return fmt.Errorf("line %d: argument is not scalar or list", node.Line)
+	}
+
+	return nil
+}
+
+func readConfig(filePath string) (config, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return config{}, fmt.Errorf("read file(%s): %w", filePath, err)
+	}
+
+	cfg, err := parseConfig(data)
+	if err != nil {
+		return config{}, err
+	}
+
+	cfg.Dir, err = filepath.Abs(filepath.Dir(filePath))
+	if err != nil {
+		return config{}, fmt.Errorf("get config dir: %w", err)
+	}
+
+	return cfg, nil
+}
+
+func parseConfig(data []byte) (config, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var cfg config
+	if err := decoder.Decode(&cfg); err != nil {
+		return config{}, fmt.Errorf("decode: %w", err)
+	}
+
+	for i, item := range cfg.Jobs {
+		if item.Command == "" {
+			return config{}, fmt.Errorf("job %d(%s): command is not set", i, item)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,185 @@
+package directive_runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/command"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+)
+
+const defaultPattern = "./..."
+
+type commandArgs struct {
+	patterns []string
+	check    bool
+	dryRun   bool
+	output   string
+}
+
+type Command struct {
+	args     commandArgs
+	commands []command.Command
+	cache    *astpkg.PackageCache
+	// running are the directives being executed, they are shared with the nested generate commands
+	// to stop the recursion
+	running map[string]struct{}
+}
+
+// New returns the command executing the codegen directives of the packages in one process,
+// the directives can run the commands and the generate command itself.
+func New(commands ...command.Command) *Command {
+	return &Command{
+		commands: commands,
+	}
+}
+
+func (c *Command) Name() string {
+	return "generate"
+}
+
+func (c *Command) ShortName() string {
+	return "g"
+}
+
+// SetPackageCache sets the cache of the parsed packages shared between the commands.
+func (c *Command) SetPackageCache(cache *astpkg.PackageCache) {
+	c.cache = cache
+}
+
+// SetArgs sets the packages patterns, ./... by default.
+func (c *Command) SetArgs(args []string) {
+	c.args.patterns = args
+}
+
+func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
+	flagSetter.Flags().BoolVarP(
+		&c.args.check,
+		command.CheckFlag,
+		"",
+		false,
+		"check that the files generated by all directives are up to date without writing them",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.dryRun,
+		command.DryRunFlag,
+		"",
+		false,
+		"list the files which would be written or changed by all directives without writing them",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.output,
+		command.OutputFlag,
+		"",
+		"",
+		"print the code generated by all directives to stdout instead of writing the files, the only supported value is -",
+	)
+
+	return nil
+}
+
+func (c *Command) Execute() error {
+	patterns := c.args.patterns
+	if len(patterns) == 0 {
+		patterns = []string{defaultPattern}
+	}
+
+	directives, err := findDirectives(patterns)
+	if err != nil {
+		return fmt.Errorf("find directives: %w", err)
+	}
+
+	return c.run(directives)
+}
+
+// run executes the directives in the directories of their packages as go generate does.
+func (c *Command) run(directives []directive) (resErr error) {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working dir: %w", err)
+	}
+	defer func() {
+		if err := os.Chdir(workDir); err != nil {
+			resErr = errors.Join(resErr, fmt.Errorf("restore working dir: %w", err))
+		}
+	}()
+
+	cache := c.cache
+	if cache == nil {
+		cache = astpkg.NewPackageCache()
+	}
+	if c.running == nil {
+		c.running = make(map[string]struct{})
+	}
+
+	// the check mode runs all directives to report all outdated files
+	outdated := make([]error, 0)
+
+	for _, item := range directives {
+		if _, ok := c.running[item.String()]; ok {
+			return fmt.Errorf("directive(%s): recursive execution", item)
+		}
+
+		c.running[item.String()] = struct{}{}
+		err := c.runDirective(cache, item)
+		delete(c.running, item.String())
+		if err == nil {
+			continue
+		}
+
+		err = fmt.Errorf("directive(%s): %w", item, err)
+		if c.args.check && errors.Is(err, filepkg.ErrOutdated) {
+			outdated = append(outdated, err)
+			continue
+		}
+
+		return err
+	}
+
+	return errors.Join(outdated...)
+}
+
+func (c *Command) runDirective(cache *astpkg.PackageCache, item directive) error {
+	cmd, ok := c.findCommand(item.Args[0])
+	if !ok {
+		return fmt.Errorf("unknown command: %s", item.Args[0])
+	}
+
+	if err := os.Chdir(item.Dir); err != nil {
+		return fmt.Errorf("change working dir: %w", err)
+	}
+
+	return command.RunJob(command.RunJobParams{
+		Command: cmd,
+		SetFlags: func(flagSet *command.FlagSet) error {
+			setter, ok := cmd.(command.ArgsSetter)
+			if !ok {
+				return flagSet.Parse(item.Args[1:])
+			}
+
+			args, err := flagSet.ParseArgs(item.Args[1:])
+			if err != nil {
+				return err
+			}
+			setter.SetArgs(args)
+
+			return nil
+		},
+		Check:  c.args.check,
+		DryRun: c.args.dryRun,
+		Output: c.args.output,
+		Cache:  cache,
+	})
+}
+
+// findCommand returns the command by the name, the generate command is executed by the new
+// command, so the flags of the running command are not changed.
+func (c *Command) findCommand(name string) (command.Command, bool) {
+	if name == c.Name() || name == c.ShortName() {
+		return &Command{commands: c.commands, running: c.running}, true
+	}
+
+	return command.Find(c.commands, name)
+}
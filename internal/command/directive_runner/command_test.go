@@ -0,0 +1,257 @@
+package directive_runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/khevse/codegen/internal/pkg/command"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCommand struct {
+	name string
+
+	value    string
+	executed []string
+	dirs     []string
+}
+
+func (c *fakeCommand) Name() string {
+	return c.name
+}
+
+func (c *fakeCommand) ShortName() string {
+	return c.name[:1]
+}
+
+func (c *fakeCommand) InitFlags(flagSetter command.FlagSetter) error {
+	flagSetter.Flags().StringVarP(&c.value, "value", "v", "", "")
+	return nil
+}
+
+func (c *fakeCommand) Execute() error {
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	c.executed = append(c.executed, c.value)
+	c.dirs = append(c.dirs, dir)
+
+	return nil
+}
+
+func TestSplitArgs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		res, err := splitArgs(` interface  "--type=a.B=C" --target-dir="./a b" "a\"b"`)
+		require.NoError(t, err)
+		require.Equal(t, []string{"interface", "--type=a.B=C", `--target-dir="./a`, `b"`, `a"b`}, res)
+	})
+
+	t.Run("unterminated quoted string", func(t *testing.T) {
+		_, err := splitArgs(`interface "--type`)
+		require.EqualError(t, err, `unterminated quoted string: "--type`)
+	})
+}
+
+func TestParseDirective(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Line   string
+		Want   []string
+		WantOK bool
+	}{
+		"codegen directive": {
+			Line:   "//codegen:generate interface --type=a.B",
+			Want:   []string{"interface", "--type=a.B"},
+			WantOK: true,
+		},
+		"go generate": {
+			Line:   "//go:generate codegen interface --type=a.B",
+			Want:   []string{"interface", "--type=a.B"},
+			WantOK: true,
+		},
+		"go generate by path": {
+			Line:   "//go:generate ../bin/codegen mock -i a.B",
+			Want:   []string{"mock", "-i", "a.B"},
+			WantOK: true,
+		},
+		"go run": {
+			Line:   "//go:generate go run -mod=mod github.com/khevse/codegen/cmd/codegen@v1.0.0 mock -i a.B",
+			Want:   []string{"mock", "-i", "a.B"},
+			WantOK: true,
+		},
+		"go run another program": {
+			Line: "//go:generate go run golang.org/x/tools/cmd/stringer -type=Kind",
+		},
+		"another program": {
+			Line: "//go:generate stringer -type=Kind",
+		},
+		"without command": {
+			Line: "//go:generate codegen",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res, ok, err := parseDirective(tc.Line)
+			require.NoError(t, err)
+			require.Equal(t, tc.WantOK, ok)
+			require.Equal(t, tc.Want, res)
+		})
+	}
+}
+
+func TestFindDirectives(t *testing.T) {
+	t.Parallel()
+
+	dir, err := filepath.Abs("testdata/directivepkg")
+	require.NoError(t, err)
+	file := filepath.Join(dir, "directives.go")
+	extTestFile := filepath.Join(dir, "directives_ext_test.go")
+	testFile := filepath.Join(dir, "directives_test.go")
+
+	res, err := findDirectives([]string{"./testdata/directivepkg"})
+	require.NoError(t, err)
+	require.Equal(t,
+		[]directive{
+			{
+				Package: "github.com/khevse/codegen/internal/command/directive_runner/testdata/directivepkg",
+				Dir:     dir,
+				File:    file,
+				Line:    3,
+				Args: []string{
+					"interface",
+					"--type=github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStructWithMethods",
+					"--target-dir=.",
+				},
+			},
+			{
+				Package: "github.com/khevse/codegen/internal/command/directive_runner/testdata/directivepkg",
+				Dir:     dir,
+				File:    file,
+				Line:    4,
+				Args:    []string{"mock", "-i", "github.com/khevse/codegen/tests/mainpkg.IObject1", "-p", "."},
+			},
+			{
+				Package: "github.com/khevse/codegen/internal/command/directive_runner/testdata/directivepkg",
+				Dir:     dir,
+				File:    file,
+				Line:    7,
+				Args: []string{
+					"m",
+					"--interface-type=github.com/khevse/codegen/tests/mainpkg.IFactory",
+					"--suffix=_directivepkg",
+					"-p",
+					".",
+				},
+			},
+			{
+				Package: "github.com/khevse/codegen/internal/command/directive_runner/testdata/directivepkg",
+				Dir:     dir,
+				File:    extTestFile,
+				Line:    3,
+				Args: []string{
+					"m",
+					"--interface-type=github.com/khevse/codegen/tests/mainpkg.IObject2",
+					"--suffix=_directivepkg_test",
+					"-p",
+					".",
+				},
+			},
+			{
+				Package: "github.com/khevse/codegen/internal/command/directive_runner/testdata/directivepkg",
+				Dir:     dir,
+				File:    testFile,
+				Line:    3,
+				Args: []string{
+					"m",
+					"--interface-type=github.com/khevse/codegen/tests/mainpkg.IObject2",
+					"--suffix=_directivepkg",
+					"-p",
+					".",
+				},
+			},
+		},
+		res,
+	)
+}
+
+func TestRun(t *testing.T) {
+	dir, err := filepath.Abs("testdata/directivepkg")
+	require.NoError(t, err)
+	workDir, err := os.Getwd()
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		cmd := &fakeCommand{name: "first"}
+
+		err := New(cmd).run([]directive{
+			{Dir: dir, File: "a.go", Line: 1, Args: []string{"first", "--value=1"}},
+			{Dir: workDir, File: "b.go", Line: 2, Args: []string{"f", "-v", "2"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "2"}, cmd.executed)
+		require.Equal(t, []string{dir, workDir}, cmd.dirs)
+
+		currentDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.Equal(t, workDir, currentDir)
+	})
+
+	t.Run("nested generate", func(t *testing.T) {
+		nestedDir, err := filepath.Abs("testdata/nestedpkg")
+		require.NoError(t, err)
+
+		cmd := &fakeCommand{name: "first"}
+
+		err = New(cmd).run([]directive{
+			{Dir: workDir, File: "a.go", Line: 1, Args: []string{"generate", "./testdata/nestedpkg"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"nested"}, cmd.executed)
+		require.Equal(t, []string{nestedDir}, cmd.dirs)
+
+		currentDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.Equal(t, workDir, currentDir)
+	})
+
+	t.Run("recursive generate", func(t *testing.T) {
+		recursiveDir, err := filepath.Abs("testdata/recursivepkg")
+		require.NoError(t, err)
+		file := filepath.Join(recursiveDir, "recursive.go")
+
+		err = New(&fakeCommand{name: "first"}).run([]directive{
+			{Dir: recursiveDir, File: "a.go", Line: 1, Args: []string{"g", "."}},
+		})
+		require.EqualError(t, err, "directive(a.go:1): execute(generate): directive("+file+":3): execute(generate): "+
+			"directive("+file+":3): recursive execution")
+
+		currentDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.Equal(t, workDir, currentDir)
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		err := New(&fakeCommand{name: "first"}).run([]directive{
+			{Dir: dir, File: "a.go", Line: 1, Args: []string{"unknown"}},
+		})
+		require.EqualError(t, err, "directive(a.go:1): unknown command: unknown")
+	})
+
+	t.Run("unexpected arguments", func(t *testing.T) {
+		err := New(&fakeCommand{name: "first"}).run([]directive{
+			{Dir: dir, File: "a.go", Line: 1, Args: []string{"first", "value"}},
+		})
+		require.EqualError(t, err, "directive(a.go:1): unexpected arguments: [value]")
+
+		currentDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.Equal(t, workDir, currentDir)
+	})
+}
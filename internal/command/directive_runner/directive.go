@@ -0,0 +1,219 @@
+package directive_runner
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+)
+
+const (
+	// goGenerateDirective is the go generate directive: //go:generate codegen interface --type=...
+	goGenerateDirective = "//go:generate "
+	// codegenDirective is the directive which is ignored by go generate: //codegen:generate interface --type=...
+	codegenDirective = "//codegen:generate "
+	// programName is the name of the codegen binary and the last element of its package path
+	programName = "codegen"
+)
+
+type directive struct {
+	Package string
+	Dir     string
+	File    string
+	Line    int
+	// Args are the command name and the flags
+	Args []string
+}
+
+func (d directive) String() string {
+	return fmt.Sprintf("%s:%d", d.File, d.Line)
+}
+
+// findDirectives returns the codegen directives of the packages grouped by the package.
+func findDirectives(patterns []string) ([]directive, error) {
+	pkgList, err := astpkg.ListPackageFiles(patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("list packages: %w", err)
+	}
+
+	res := make([]directive, 0)
+	for _, pkg := range pkgList {
+		// the test files are processed as go generate does, $GOPACKAGE of the external
+		// test files is the name of the external test package
+		files := make(map[string]string, len(pkg.GoFiles)+len(pkg.TestGoFiles)+len(pkg.XTestGoFiles))
+		for _, filePath := range slices.Concat(pkg.GoFiles, pkg.TestGoFiles) {
+			files[filePath] = pkg.Name
+		}
+		for _, filePath := range pkg.XTestGoFiles {
+			files[filePath] = pkg.Name + "_test"
+		}
+
+		for _, filePath := range slices.Sorted(maps.Keys(files)) {
+			list, err := readFileDirectives(pkg, files[filePath], filePath)
+			if err != nil {
+				return nil, fmt.Errorf("read directives(%s): %w", filePath, err)
+			}
+
+			res = append(res, list...)
+		}
+	}
+
+	return res, nil
+}
+
+func readFileDirectives(pkg astpkg.PackageFiles, pkgName, filePath string) ([]directive, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	res := make([]directive, 0)
+
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, goGenerateDirective) && !strings.HasPrefix(line, codegenDirective) {
+			continue
+		}
+
+		expanded := os.Expand(line, func(name string) string {
+			switch name {
+			case "GOFILE":
+				return filepath.Base(filePath)
+			case "GOLINE":
+				return strconv.Itoa(lineNum)
+			case "GOPACKAGE":
+				return pkgName
+			case "DOLLAR":
+				return "$"
+			default:
+				return os.Getenv(name)
+			}
+		})
+
+		args, ok, err := parseDirective(expanded)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		if !ok {
+			continue
+		}
+
+		res = append(res, directive{
+			Package: pkg.Path,
+			Dir:     pkg.Dir,
+			File:    filePath,
+			Line:    lineNum,
+			Args:    args,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	return res, nil
+}
+
+// parseDirective returns the arguments of the codegen command, it reports false if the
+// directive runs another program.
+func parseDirective(line string) ([]string, bool, error) {
+	if rest, ok := strings.CutPrefix(line, codegenDirective); ok {
+		args, err := splitArgs(rest)
+		if err != nil || len(args) == 0 {
+			return nil, false, err
+		}
+
+		return args, true, nil
+	}
+
+	words, err := splitArgs(strings.TrimPrefix(line, goGenerateDirective))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(words) < 2 {
+		return nil, false, nil
+	}
+
+	// codegen interface ...
+	if strings.TrimSuffix(filepath.Base(words[0]), ".exe") == programName {
+		return words[1:], true, nil
+	}
+
+	// go run github.com/khevse/codegen/cmd/codegen@v1.0.0 interface ...
+	if len(words) > 2 && words[0] == "go" && words[1] == "run" {
+		for i := 2; i < len(words); i++ {
+			if strings.HasPrefix(words[i], "-") {
+				continue
+			}
+
+			pkgPath, _, _ := strings.Cut(words[i], "@")
+			if path.Base(pkgPath) != programName {
+				return nil, false, nil
+			}
+
+			if i+1 == len(words) {
+				return nil, false, nil
+			}
+
+			return words[i+1:], true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// splitArgs splits the line to the space separated words or the double quoted strings as go generate does.
+func splitArgs(line string) ([]string, error) {
+	res := make([]string, 0)
+
+	for {
+		line = strings.TrimLeftFunc(line, unicode.IsSpace)
+		if line == "" {
+			return res, nil
+		}
+
+		if line[0] == '"' {
+			end := 1
+			for ; end < len(line); end++ {
+				if line[end] == '\\' {
+					end++
+					continue
+				}
+				if line[end] == '"' {
+					break
+				}
+			}
+			if end >= len(line) {
+				return nil, fmt.Errorf("unterminated quoted string: %s", line)
+			}
+
+			word, err := strconv.Unquote(line[:end+1])
+			if err != nil {
+				return nil, fmt.Errorf("unquote(%s): %w", line[:end+1], err)
+			}
+
+			res = append(res, word)
+			line = line[end+1:]
+			continue
+		}
+
+		end := strings.IndexFunc(line, unicode.IsSpace)
+		if end == -1 {
+			end = len(line)
+		}
+
+		res = append(res, line[:end])
+		line = line[end:]
+	}
+}
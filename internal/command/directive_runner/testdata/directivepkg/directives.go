@@ -0,0 +1,10 @@
+package directivepkg
+
+//go:generate codegen interface --type=github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStructWithMethods --target-dir=.
+//go:generate go run -mod=mod github.com/khevse/codegen/cmd/codegen@latest mock -i github.com/khevse/codegen/tests/mainpkg.IObject1 -p .
+//go:generate stringer -type=Kind
+
+//codegen:generate m "--interface-type=github.com/khevse/codegen/tests/mainpkg.IFactory" --suffix=_$GOPACKAGE -p .
+
+// Kind is the kind.
+type Kind int
@@ -0,0 +1,3 @@
+package directivepkg_test
+
+//codegen:generate m --interface-type=github.com/khevse/codegen/tests/mainpkg.IObject2 --suffix=_$GOPACKAGE -p .
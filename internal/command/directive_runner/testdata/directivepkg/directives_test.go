@@ -0,0 +1,3 @@
+package directivepkg
+
+//codegen:generate m --interface-type=github.com/khevse/codegen/tests/mainpkg.IObject2 --suffix=_$GOPACKAGE -p .
@@ -0,0 +1,3 @@
+package nestedpkg
+
+//codegen:generate first --value=nested
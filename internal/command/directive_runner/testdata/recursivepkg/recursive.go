@@ -0,0 +1,3 @@
+package recursivepkg
+
+//codegen:generate generate .
@@ -0,0 +1,213 @@
+package func_adapter
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/command"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+	"github.com/khevse/codegen/internal/pkg/interfacepkg"
+	"github.com/khevse/codegen/internal/pkg/stringspkg"
+)
+
+type commandArgs struct {
+	interfaceType string
+	targetDir     string
+	fileSuffix    string
+	onNil         string
+	check         bool
+	dryRun        bool
+	output        string
+	templateFile  string
+
+	packageCache *astpkg.PackageCache
+}
+
+type Command struct {
+	args commandArgs
+}
+
+func New() *Command {
+	return new(Command)
+}
+
+func (c *Command) Name() string {
+	return "func-adapter"
+}
+
+func (c *Command) ShortName() string {
+	return "fa"
+}
+
+// SetPackageCache sets the cache of the parsed packages shared between the commands.
+func (c *Command) SetPackageCache(cache *astpkg.PackageCache) {
+	c.args.packageCache = cache
+}
+
+// DefaultTemplate returns the default template of the generated file.
+func (c *Command) DefaultTemplate() ([]byte, error) {
+	return defaultTemplate()
+}
+
+func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
+	const (
+		flagInterfaceType = "interface-type"
+		flagTargetDir     = "target-dir"
+		flagFileSuffix    = "suffix"
+		flagOnNil         = "on-nil"
+		flagCheck         = "check"
+		flagDryRun        = "dry-run"
+		flagOutput        = "output"
+		flagTemplateFile  = "template"
+	)
+
+	flagSetter.Flags().StringVarP(
+		&c.args.interfaceType,
+		flagInterfaceType,
+		"i",
+		"",
+		"interface type for adapters generation. Examples: <package>.<InterfaceName>; <package>.<InterfaceName>=<Name>; <package>.<InterfaceName1>,<package>.<InterfaceName2>=<Name2>",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.targetDir,
+		flagTargetDir,
+		"p",
+		"",
+		"target dir for the adapters",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.fileSuffix,
+		flagFileSuffix,
+		"",
+		"",
+		"result file suffix",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.onNil,
+		flagOnNil,
+		"",
+		onNilPanic,
+		"behavior of the methods of <Name>Funcs if the function is not set: panic or zero (return the zero values)",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.check,
+		flagCheck,
+		"",
+		false,
+		"check that the generated files are up to date without writing them, the differences are printed",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.dryRun,
+		flagDryRun,
+		"",
+		false,
+		"list the files which would be written or changed without writing them",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.output,
+		flagOutput,
+		"",
+		"",
+		"print the generated code to stdout instead of writing the files, the only supported value is -",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.templateFile,
+		flagTemplateFile,
+		"",
+		"",
+		"custom template file used instead of the default template",
+	)
+
+	for _, flagName := range []string{flagInterfaceType, flagTargetDir} {
+		if err := flagSetter.MarkFlagRequired(flagName); err != nil {
+			return fmt.Errorf("mark flag as required(%s): %w", flagName, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Command) Execute() error {
+	writer, err := filepkg.NewWriter(filepkg.WriterParams{
+		Check:      c.args.check,
+		DryRun:     c.args.dryRun,
+		OutputFile: c.args.output,
+		Output:     os.Stdout,
+	})
+	if err != nil {
+		return fmt.Errorf("new writer: %w", err)
+	}
+
+	targetDir, err := filepath.Abs(c.args.targetDir)
+	if err != nil {
+		return fmt.Errorf("get target dir full path: %w", err)
+	}
+
+	fileList, err := prepareFileList(c.args)
+	if err != nil {
+		return fmt.Errorf("prepare adapters specifications: %w", err)
+	}
+
+	for _, item := range fileList {
+		fileName := fmt.Sprintf("%s_funcs%s.go", stringspkg.ToSnakeCase(item.Adapter.Name), c.args.fileSuffix)
+		filePath := filepath.Join(targetDir, fileName)
+
+		g := generator{
+			Package:  filepath.Base(targetDir),
+			Imports:  item.Imports,
+			Adapter:  item.Adapter,
+			Template: c.args.templateFile,
+		}
+
+		buf := bytes.NewBuffer(nil)
+		if err := g.Generate(buf); err != nil {
+			return fmt.Errorf("generate adapter(%s): %w", item.Adapter.Name, err)
+		}
+
+		if err := writer.WriteFile(filePath, buf.Bytes()); err != nil {
+			return fmt.Errorf("write adapter(%s): %w", item.Adapter.Name, err)
+		}
+	}
+
+	return writer.Err()
+}
+
+type adapterFile struct {
+	Imports astpkg.ImportList
+	Adapter adapterSpec
+}
+
+func prepareFileList(args commandArgs) ([]adapterFile, error) {
+	interfaceTypeList, err := interfacepkg.ParseInterfaceTypeList(args.interfaceType, "")
+	if err != nil {
+		return nil, fmt.Errorf("parse interface types: %w", err)
+	}
+
+	fileList, err := interfacepkg.PrepareFileList(interfacepkg.PrepareFileListParams{
+		InterfaceTypes: interfaceTypeList,
+		TargetDir:      args.targetDir,
+		Reserved:       reservedNames,
+		Cache:          args.packageCache,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]adapterFile, 0, len(fileList))
+	for _, item := range fileList {
+		adapter, err := newAdapterSpec(item.Spec, args.onNil)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, adapterFile{
+			Imports: item.Imports,
+			Adapter: adapter,
+		})
+	}
+
+	return res, nil
+}
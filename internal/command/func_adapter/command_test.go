@@ -0,0 +1,165 @@
+package func_adapter
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute(t *testing.T) {
+	args := commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/decoratorpkg.IService",
+		targetDir:     "./",
+		fileSuffix:    "_generated",
+		onNil:         onNilPanic,
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "i_service_funcs_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package func_adapter
+
+import (
+	context "context"
+	decoratorpkg "github.com/khevse/codegen/tests/decoratorpkg"
+)
+
+var (
+	_ decoratorpkg.IService = IServiceFuncs{}
+)
+
+// IServiceFuncs implements decoratorpkg.IService by the functions, the method panics if the function is not set: IService service of the users
+type IServiceFuncs struct {
+	CloseFunc func()
+	FindFunc  func(ctx context.Context, ids ...int) (r0 []decoratorpkg.User, r1 error)
+	LoginFunc func(ctx context.Context, user string, password string) (token string, err error)
+	NameFunc  func() (r0 string)
+	PingFunc  func() (r0 error)
+}
+
+// Close calls CloseFunc.
+func (f IServiceFuncs) Close() {
+	if f.CloseFunc == nil {
+		panic("IServiceFuncs.CloseFunc is not set")
+	}
+
+	f.CloseFunc()
+}
+
+// Find calls FindFunc.
+func (f IServiceFuncs) Find(ctx context.Context, ids ...int) (r0 []decoratorpkg.User, r1 error) {
+	if f.FindFunc == nil {
+		panic("IServiceFuncs.FindFunc is not set")
+	}
+
+	return f.FindFunc(ctx, ids...)
+}
+
+// Login calls LoginFunc.
+func (f IServiceFuncs) Login(ctx context.Context, user string, password string) (token string, err error) {
+	if f.LoginFunc == nil {
+		panic("IServiceFuncs.LoginFunc is not set")
+	}
+
+	return f.LoginFunc(ctx, user, password)
+}
+
+// Name calls NameFunc.
+func (f IServiceFuncs) Name() (r0 string) {
+	if f.NameFunc == nil {
+		panic("IServiceFuncs.NameFunc is not set")
+	}
+
+	return f.NameFunc()
+}
+
+// Ping calls PingFunc.
+func (f IServiceFuncs) Ping() (r0 error) {
+	if f.PingFunc == nil {
+		panic("IServiceFuncs.PingFunc is not set")
+	}
+
+	return f.PingFunc()
+}
+`,
+		string(data),
+	)
+}
+
+func TestExecuteSingleMethod(t *testing.T) {
+	args := commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/mainpkg.IObject1=Stringer",
+		targetDir:     "./",
+		fileSuffix:    "_generated",
+		onNil:         onNilZero,
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "stringer_funcs_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package func_adapter
+
+import (
+	mainpkg "github.com/khevse/codegen/tests/mainpkg"
+)
+
+var (
+	_ mainpkg.IObject1 = StringerFunc(nil)
+	_ mainpkg.IObject1 = StringerFuncs{}
+)
+
+// StringerFunc is the function implementing mainpkg.IObject1: IObject1 comment .
+type StringerFunc func() (r0 string)
+
+// String calls f.
+func (f StringerFunc) String() (r0 string) {
+	return f()
+}
+
+// StringerFuncs implements mainpkg.IObject1 by the functions, the method returns the zero values if the function is not set: IObject1 comment .
+type StringerFuncs struct {
+	StringFunc func() (r0 string)
+}
+
+// String calls StringFunc.
+func (f StringerFuncs) String() (r0 string) {
+	if f.StringFunc == nil {
+		return r0
+	}
+
+	return f.StringFunc()
+}
+`,
+		string(data),
+	)
+}
+
+func TestPrepareFileListInvalidOnNil(t *testing.T) {
+	t.Parallel()
+
+	_, err := prepareFileList(commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/mainpkg.IObject1",
+		targetDir:     "./",
+		onNil:         "skip",
+	})
+	require.EqualError(t, err, `unknown behavior of the nil functions "skip", supported: [panic zero]`)
+}
@@ -0,0 +1,53 @@
+package func_adapter
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/khevse/codegen/internal/pkg/interfacepkg"
+)
+
+const (
+	onNilPanic = "panic"
+	onNilZero  = "zero"
+)
+
+var onNilValues = []string{onNilPanic, onNilZero}
+
+// reservedNames are the names used by the generated methods, the arguments with these names are renamed.
+var reservedNames = []string{"f"}
+
+type adapterSpec struct {
+	Name          string
+	InterfaceName string
+	TypeName      string
+	TypeParams    string
+	TypeArgs      string
+	Comment       string
+	Methods       []interfacepkg.Method
+	// Panic is true if the methods of the struct of the functions panic if the function is not set,
+	// they return the zero values otherwise.
+	Panic bool
+}
+
+// IsSingleMethod returns true if the function type is generated for the interface.
+func (s adapterSpec) IsSingleMethod() bool {
+	return len(s.Methods) == 1
+}
+
+func newAdapterSpec(spec interfacepkg.Spec, onNil string) (adapterSpec, error) {
+	if !slices.Contains(onNilValues, onNil) {
+		return adapterSpec{}, fmt.Errorf("unknown behavior of the nil functions %q, supported: %v", onNil, onNilValues)
+	}
+
+	return adapterSpec{
+		Name:          spec.Name,
+		InterfaceName: spec.InterfaceName,
+		TypeName:      spec.TypeName,
+		TypeParams:    spec.TypeParams,
+		TypeArgs:      spec.TypeArgs,
+		Comment:       spec.Comment,
+		Methods:       spec.Methods,
+		Panic:         onNil == onNilPanic,
+	}, nil
+}
@@ -0,0 +1,176 @@
+package interface_creator
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/samber/lo"
+)
+
+// sourceSpec is the source type of the interface used by the assertions in the source package.
+type sourceSpec struct {
+	PackagePath string
+	Dir         string
+	TypeName    string
+	// TypeParams are the type parameters of the generic type, the constraints are rendered
+	// with the imports of the file with the assertions.
+	TypeParams []*astpkg.Field
+	// TypeArgs are the type arguments of the generic type: [K, V].
+	TypeArgs string
+	// Value is true if the interface is implemented by the value of the type.
+	Value bool
+}
+
+func newSourceSpec(pkg *astpkg.Package, typeDecl *astpkg.TypeDecl, set methodSet) *sourceSpec {
+	return &sourceSpec{
+		PackagePath: pkg.Path,
+		Dir:         pkg.Dir,
+		TypeName:    typeDecl.Name,
+		TypeParams:  typeDecl.TypeParams,
+		TypeArgs:    astpkg.TypeParamsArgs(typeDecl.TypeParams),
+		Value:       set == methodSetValue,
+	}
+}
+
+// AssertSpec is the specification of the file with the assertions of the types in the source package.
+type AssertSpec struct {
+	FilePath string
+	assertGenerator
+}
+
+type newAssertSpecListParams struct {
+	targetDir   string
+	packageName string
+	fileSuffix  string
+	// imports are the imports of the generated interfaces
+	imports    astpkg.ImportList
+	interfaces []objectSpec
+}
+
+// newAssertSpecList returns the files with the assertions of the types, one file per source package.
+// The source package importing the target package makes the import cycle if the target package
+// depends on the source package, in this case the assertions are generated to the external test package.
+func newAssertSpecList(params newAssertSpecListParams) ([]*AssertSpec, error) {
+	targetPackage, err := astpkg.GetPackagePath(params.targetDir)
+	if err != nil {
+		return nil, fmt.Errorf("get target package: %w", err)
+	}
+
+	interfaces := lo.Filter(params.interfaces, func(item objectSpec, _ int) bool {
+		return item.Source != nil
+	})
+	if len(interfaces) == 0 {
+		return nil, nil
+	}
+
+	importPathList := lo.FilterMap(params.imports, func(item astpkg.Import, _ int) (string, bool) {
+		return item.Path, item.Path != ""
+	})
+	sourcePathList := lo.Uniq(lo.Map(interfaces, func(item objectSpec, _ int) string {
+		return item.Source.PackagePath
+	}))
+	slices.Sort(sourcePathList)
+
+	depsList, err := astpkg.ListPackageDeps(lo.Uniq(slices.Concat([]string{targetPackage}, importPathList, sourcePathList))...)
+	if err != nil {
+		return nil, fmt.Errorf("list packages dependencies: %w", err)
+	}
+
+	depsByPath := lo.SliceToMap(depsList, func(item astpkg.PackageDeps) (string, astpkg.PackageDeps) {
+		return item.Path, item
+	})
+
+	// dependsOn reports whether the target package with the generated interfaces depends on the package
+	dependsOn := func(pkgPath string) bool {
+		if slices.Contains(importPathList, pkgPath) || slices.Contains(depsByPath[targetPackage].Deps, pkgPath) {
+			return true
+		}
+
+		return lo.ContainsBy(importPathList, func(item string) bool {
+			return slices.Contains(depsByPath[item].Deps, pkgPath)
+		})
+	}
+
+	res := make([]*AssertSpec, 0, len(sourcePathList))
+	for _, sourcePath := range sourcePathList {
+		sourceInterfaces := lo.Filter(interfaces, func(item objectSpec, _ int) bool {
+			return item.Source.PackagePath == sourcePath
+		})
+
+		isTest := sourcePath != targetPackage && dependsOn(sourcePath)
+
+		importPaths := make([]string, 0, 2)
+		if isTest {
+			importPaths = append(importPaths, sourcePath)
+		}
+		if sourcePath != targetPackage {
+			importPaths = append(importPaths, targetPackage)
+		}
+		for _, item := range sourceInterfaces {
+			for _, p := range item.Source.TypeParams {
+				for _, imp := range p.Type.Imports() {
+					if imp.Path != "" && (isTest || imp.Path != sourcePath) {
+						importPaths = append(importPaths, imp.Path)
+					}
+				}
+			}
+		}
+
+		imports, err := astpkg.NewImportListWithUniqAlias(lo.Uniq(importPaths))
+		if err != nil {
+			return nil, fmt.Errorf("new imports list(%s): %w", sourcePath, err)
+		}
+
+		// the declarations of the source package are unqualified in the source package
+		typeParamsImports := imports
+		if !isTest {
+			typeParamsImports = append(slices.Clone(imports), astpkg.NewImport("", sourcePath))
+		}
+
+		sourceQualifier, err := packageQualifier(sourcePath, lo.Ternary(isTest, "", sourcePath), imports)
+		if err != nil {
+			return nil, err
+		}
+
+		targetQualifier, err := packageQualifier(targetPackage, sourcePath, imports)
+		if err != nil {
+			return nil, err
+		}
+
+		assertions := make([]assertionSpec, 0, len(sourceInterfaces))
+		for _, item := range sourceInterfaces {
+			typeParams, err := astpkg.TypeParamsDeclWithImports(item.Source.TypeParams, typeParamsImports)
+			if err != nil {
+				return nil, fmt.Errorf("type params(%s): %w", item.Source.TypeName, err)
+			}
+
+			typeName := sourceQualifier + item.Source.TypeName + item.Source.TypeArgs
+			assertions = append(assertions, assertionSpec{
+				TypeParams: typeParams,
+				Interface:  targetQualifier + item.Name + item.Source.TypeArgs,
+				Value:      lo.Ternary(item.Source.Value, typeName+"{}", fmt.Sprintf("(*%s)(nil)", typeName)),
+				Qualifier:  sourceQualifier,
+			})
+		}
+
+		packageName := depsByPath[sourcePath].Name
+		fileName := fmt.Sprintf("%s_interfaces%s_assert.go", params.packageName, params.fileSuffix)
+		if isTest {
+			packageName += "_test"
+			fileName = fmt.Sprintf("%s_interfaces%s_assert_test.go", params.packageName, params.fileSuffix)
+		}
+
+		res = append(res, &AssertSpec{
+			FilePath: filepath.Join(sourceInterfaces[0].Source.Dir, fileName),
+			assertGenerator: assertGenerator{
+				Package:    packageName,
+				Imports:    imports,
+				Assertions: assertions,
+			},
+		})
+	}
+
+	return res, nil
+}
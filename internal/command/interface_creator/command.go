@@ -1,20 +1,42 @@
 package interface_creator
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/khevse/codegen/internal/pkg/astpkg"
 	"github.com/khevse/codegen/internal/pkg/command"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+	"github.com/khevse/codegen/internal/pkg/filterpkg"
 	"github.com/samber/lo"
 )
 
 type commandArgs struct {
-	fromType   string
-	targetDir  string
-	fileSuffix string
+	fromType     string
+	funcs        string
+	funcPrefix   string
+	funcRegex    string
+	targetDir    string
+	fileSuffix   string
+	promoted     bool
+	methodSet    string
+	assert       bool
+	check        bool
+	dryRun       bool
+	output       string
+	templateFile string
+	annotated    string
+	filter       filterpkg.Params
+
+	// methodFilter is shared by the generations of the annotated types to check the rules once.
+	methodFilter *filterpkg.MethodFilter
+	packageCache *astpkg.PackageCache
 }
 
 type Command struct {
@@ -33,11 +55,36 @@ func (c *Command) ShortName() string {
 	return "i"
 }
 
+// SetPackageCache sets the cache of the parsed packages shared between the commands.
+func (c *Command) SetPackageCache(cache *astpkg.PackageCache) {
+	c.args.packageCache = cache
+}
+
+// DefaultTemplate returns the default template of the generated file.
+func (c *Command) DefaultTemplate() ([]byte, error) {
+	return defaultTemplate()
+}
+
 func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
 	const (
-		flagFromType   = "type"
-		flagTargetDir  = "target-dir"
-		flagFileSuffix = "suffix"
+		flagFromType     = "type"
+		flagFuncs        = "funcs"
+		flagFuncPrefix   = "func-prefix"
+		flagFuncRegex    = "func-regex"
+		flagTargetDir    = "target-dir"
+		flagFileSuffix   = "suffix"
+		flagPromoted     = "promoted"
+		flagMethodSet    = "method-set"
+		flagAssert       = "assert"
+		flagCheck        = "check"
+		flagDryRun       = "dry-run"
+		flagOutput       = "output"
+		flagTemplateFile = "template"
+		flagAnnotated    = "annotated"
+		flagInclude      = "include"
+		flagExclude      = "exclude"
+		flagIncludeRegex = "include-regex"
+		flagExcludeRegex = "exclude-regex"
 	)
 
 	flagSetter.Flags().StringVarP(
@@ -47,6 +94,27 @@ func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
 		"",
 		"type for interface generation. Examples: <package>.<TypeName>; <package>.<TypeName>=<InterfaceName>; <package>.<TypeName1>=<InterfaceName1>,<package>.<TypeName2>=<InterfaceName2>",
 	)
+	flagSetter.Flags().StringVarP(
+		&c.args.funcs,
+		flagFuncs,
+		"",
+		"",
+		"package with the exported functions for interface generation, the interface is implemented by <InterfaceName>Impl calling the functions. Examples: <package>=<InterfaceName>; <package1>=<InterfaceName1>,<package2>=<InterfaceName2>",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.funcPrefix,
+		flagFuncPrefix,
+		"",
+		"",
+		"prefix of the names of the functions collected by --funcs",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.funcRegex,
+		flagFuncRegex,
+		"",
+		"",
+		"regular expression matching the names of the functions collected by --funcs",
+	)
 	flagSetter.Flags().StringVarP(
 		&c.args.targetDir,
 		flagTargetDir,
@@ -61,61 +129,249 @@ func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
 		"",
 		"result file suffix",
 	)
-
-	for _, flagName := range []string{flagFromType, flagTargetDir} {
-		if err := flagSetter.MarkFlagRequired(flagName); err != nil {
-			return fmt.Errorf("mark flag as required(%s): %w", flagName, err)
-		}
-	}
+	flagSetter.Flags().BoolVarP(
+		&c.args.promoted,
+		flagPromoted,
+		"",
+		false,
+		"include the methods promoted from the embedded fields",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.methodSet,
+		flagMethodSet,
+		"",
+		"",
+		fmt.Sprintf(
+			"method set of the types: %s, %s or %s, the selected set adds the compile-time assertion of the type; all methods without the assertion by default",
+			methodSetValue, methodSetPointer, methodSetPointerOnly,
+		),
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.assert,
+		flagAssert,
+		"",
+		false,
+		"generate the assertions of the types to the source packages: <target package>_interfaces<suffix>_assert.go, "+
+			"or the file _test.go of the external test package if the target package imports the source package",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.check,
+		flagCheck,
+		"",
+		false,
+		"check that the generated files are up to date without writing them, the differences are printed",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.dryRun,
+		flagDryRun,
+		"",
+		false,
+		"list the files which would be written or changed without writing them",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.output,
+		flagOutput,
+		"",
+		"",
+		"print the generated code to stdout instead of writing the files, the only supported value is -",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.templateFile,
+		flagTemplateFile,
+		"",
+		"",
+		"custom template file used instead of the default template",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.annotated,
+		flagAnnotated,
+		"",
+		"",
+		"packages pattern (./...) of the types annotated by //codegen:interface [name=<InterfaceName>] [dir=<target dir>], replaces --type and --target-dir",
+	)
+	flagSetter.Flags().StringSliceVarP(
+		&c.args.filter.Include,
+		flagInclude,
+		"",
+		nil,
+		"methods included to the interfaces: <MethodName> or <TypeName>.<MethodName>, the other methods of the type are skipped",
+	)
+	flagSetter.Flags().StringSliceVarP(
+		&c.args.filter.Exclude,
+		flagExclude,
+		"",
+		nil,
+		"methods excluded from the interfaces: <MethodName> or <TypeName>.<MethodName>, the methods with //codegen:ignore are always excluded",
+	)
+	flagSetter.Flags().StringSliceVarP(
+		&c.args.filter.IncludeRegex,
+		flagIncludeRegex,
+		"",
+		nil,
+		"regular expressions of the methods included to the interfaces, matched against <TypeName>.<MethodName>",
+	)
+	flagSetter.Flags().StringSliceVarP(
+		&c.args.filter.ExcludeRegex,
+		flagExcludeRegex,
+		"",
+		nil,
+		"regular expressions of the methods excluded from the interfaces, matched against <TypeName>.<MethodName>",
+	)
 
 	return nil
 }
 
 func (c *Command) Execute() error {
-	targetDir, err := filepath.Abs(c.args.targetDir)
+	writer, err := filepkg.NewWriter(filepkg.WriterParams{
+		Check:      c.args.check,
+		DryRun:     c.args.dryRun,
+		OutputFile: c.args.output,
+		Output:     os.Stdout,
+	})
 	if err != nil {
-		return fmt.Errorf("get target dir full path: %w", err)
+		return fmt.Errorf("new writer: %w", err)
 	}
 
-	importList, objectSpecList, err := prepareObjectSpecList(c.args)
+	c.args.methodFilter, err = filterpkg.NewMethodFilter(c.args.filter)
 	if err != nil {
-		return fmt.Errorf("prepare objects specifications: %w", err)
+		return fmt.Errorf("new method filter: %w", err)
 	}
 
-	fileName := fmt.Sprintf("interfaces%s.go", c.args.fileSuffix)
-	filePath := filepath.Join(targetDir, fileName)
+	if c.args.annotated == "" && ((c.args.fromType == "" && c.args.funcs == "") || c.args.targetDir == "") {
+		return errors.New("flags --type or --funcs and --target-dir are required without --annotated")
+	}
 
-	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_APPEND|os.O_RDWR, os.ModePerm)
-	if err != nil {
-		return fmt.Errorf("create file(%s): %w", filePath, err)
+	argsList := []commandArgs{c.args}
+	if c.args.annotated != "" {
+		argsList, err = getAnnotatedArgsList(c.args)
+		if err != nil {
+			return fmt.Errorf("get annotated types: %w", err)
+		}
 	}
-	defer func() {
-		f.Close()
-	}()
-	if err := f.Truncate(0); err != nil {
-		return fmt.Errorf("truncate file: %w", err)
+
+	specList := make([]*Spec, 0, len(argsList))
+	for _, args := range argsList {
+		spec, err := newSpec(args)
+		if err != nil {
+			if c.args.annotated != "" {
+				return fmt.Errorf("generate(%s): %w", args.targetDir, err)
+			}
+			return err
+		}
+
+		specList = append(specList, spec)
 	}
 
-	g := generator{
-		Package:    filepath.Base(targetDir),
-		Imports:    importList,
-		Interfaces: objectSpecList,
+	// the files are not written if the rules of the filter have not matched any method
+	if err := c.args.methodFilter.Err(); err != nil {
+		return err
 	}
 
-	if err := g.Generate(f); err != nil {
+	for _, spec := range specList {
+		if err := write(spec, writer); err != nil {
+			return err
+		}
+	}
+
+	return writer.Err()
+}
+
+// write generates the file of the interfaces and the files of the assertions.
+func write(spec *Spec, writer *filepkg.Writer) error {
+	buf := bytes.NewBuffer(nil)
+	if err := spec.Generate(buf); err != nil {
 		return fmt.Errorf("generate: %w", err)
 	}
 
+	if err := writer.WriteFile(spec.FilePath, buf.Bytes()); err != nil {
+		return err
+	}
+
+	for _, item := range spec.Asserts {
+		buf.Reset()
+		if err := item.Generate(buf); err != nil {
+			return fmt.Errorf("generate assertions(%s): %w", item.FilePath, err)
+		}
+
+		if err := writer.WriteFile(item.FilePath, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// getAnnotatedArgsList returns the arguments of the annotated types grouped by the target dir:
+// the interfaces of the same target dir are generated to the same file.
+func getAnnotatedArgsList(args commandArgs) ([]commandArgs, error) {
+	const directiveName = "interface"
+
+	annotatedTypes, err := astpkg.FindAnnotatedTypes(args.packageCache, directiveName, args.annotated)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]commandArgs, 0)
+	for _, item := range annotatedTypes {
+		targetDir := filepath.Join(item.Package.Dir, item.Directive.Arg("dir", "."))
+		fromType := fmt.Sprintf(
+			"%s.%s=%s",
+			item.Package.Path, item.TypeDecl.Name, item.Directive.Arg("name", "I"+item.TypeDecl.Name),
+		)
+
+		idx := slices.IndexFunc(res, func(a commandArgs) bool {
+			return a.targetDir == targetDir
+		})
+		if idx != -1 {
+			res[idx].fromType += "," + fromType
+			continue
+		}
+
+		itemArgs := args
+		itemArgs.annotated = ""
+		itemArgs.fromType = fromType
+		itemArgs.targetDir = targetDir
+		res = append(res, itemArgs)
+	}
+
+	return res, nil
+}
+
 func prepareObjectSpecList(args commandArgs) (astpkg.ImportList, []objectSpec, error) {
-	fromTypeList, err := parseFromType(args.fromType)
+	fromTypeList := make([]argFromType, 0)
+	if args.fromType != "" {
+		var err error
+		fromTypeList, err = parseFromType(args.fromType)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse types names: %w", err)
+		}
+	}
+
+	funcsList := make([]argFuncs, 0)
+	if args.funcs != "" {
+		var err error
+		funcsList, err = parseFuncs(args.funcs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse functions packages: %w", err)
+		}
+	}
+
+	filter, err := newFuncFilter(args.funcPrefix, args.funcRegex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	set, err := parseMethodSet(args.methodSet)
 	if err != nil {
-		return nil, nil, fmt.Errorf("parse types names: %w", err)
+		return nil, nil, err
 	}
 
-	packageList, err := parsePackages(fromTypeList)
+	packagePathList := slices.Concat(
+		lo.Map(fromTypeList, func(item argFromType, _ int) string { return item.Package }),
+		lo.Map(funcsList, func(item argFuncs, _ int) string { return item.Package }),
+	)
+
+	packageList, err := parsePackages(args.packageCache, packagePathList)
 	if err != nil {
 		return nil, nil, fmt.Errorf("parse packages: %w", err)
 	}
@@ -147,6 +403,18 @@ func prepareObjectSpecList(args commandArgs) (astpkg.ImportList, []objectSpec, e
 			}
 
 			methods := pkg.FuncDeclList.GetByReceiverName(item.SourceName)
+			if args.promoted {
+				methods = append(methods, pkg.PromotedFuncDeclList.GetByReceiverName(item.SourceName)...)
+			}
+
+			methods = lo.Filter(methods, func(decl *astpkg.FuncDecl, _ int) bool {
+				if !set.match(decl) {
+					args.methodFilter.Skip(item.SourceName, decl.Name, "outside the method set")
+					return false
+				}
+
+				return args.methodFilter.Match(item.SourceName, decl.Name, decl.Directives)
+			})
 
 			interfaceDesc, err := newObjectSpec(item.TargetName, typeDecl, methods, imports)
 			if err != nil {
@@ -156,6 +424,41 @@ func prepareObjectSpecList(args commandArgs) (astpkg.ImportList, []objectSpec, e
 				)
 			}
 
+			if set != "" {
+				qualifier, err := packageQualifier(pkg.Path, targetPackage, imports)
+				if err != nil {
+					return nil, nil, fmt.Errorf("get type package(%s): %w", item.TargetName, err)
+				}
+
+				interfaceDesc.Assertion = newAssertionSpec(item.TargetName, typeDecl, qualifier, set)
+			}
+
+			if args.assert {
+				interfaceDesc.Source = newSourceSpec(pkg, typeDecl, set)
+			}
+
+			interfaceList = append(interfaceList, interfaceDesc)
+		}
+
+		for _, item := range funcsList {
+			if item.Package != pkg.Path {
+				continue
+			}
+
+			funcs := lo.Filter(pkg.FuncDeclList, func(decl *astpkg.FuncDecl, _ int) bool {
+				return decl.Receiver == "" && len(decl.TypeParams) == 0 &&
+					astpkg.IsExported(decl.Name) && filter.match(decl.Name) &&
+					args.methodFilter.Match(item.TargetName, decl.Name, decl.Directives)
+			})
+			if len(funcs) == 0 {
+				return nil, nil, fmt.Errorf("functions are not found in package: %s", pkg.Path)
+			}
+
+			interfaceDesc, err := newFuncsSpec(item.TargetName, pkg, funcs, imports, targetPackage)
+			if err != nil {
+				return nil, nil, fmt.Errorf("new functions specification(%s): %w", item.TargetName, err)
+			}
+
 			interfaceList = append(interfaceList, interfaceDesc)
 		}
 	}
@@ -168,6 +471,15 @@ func prepareObjectSpecList(args commandArgs) (astpkg.ImportList, []objectSpec, e
 			}
 		}
 		for _, item := range interfaceList {
+			if item.Impl != nil && item.Impl.Qualifier != "" {
+				usedImports[strings.TrimSuffix(item.Impl.Qualifier, ".")] = struct{}{}
+			}
+			if item.Assertion != nil && item.Assertion.Qualifier != "" {
+				usedImports[strings.TrimSuffix(item.Assertion.Qualifier, ".")] = struct{}{}
+			}
+			for _, p := range item.TypeParams {
+				addUsedImport(p.Type)
+			}
 			for _, method := range item.Methods {
 				for _, p := range method.Params {
 					addUsedImport(p.Type)
@@ -187,16 +499,12 @@ func prepareObjectSpecList(args commandArgs) (astpkg.ImportList, []objectSpec, e
 	return clearImports(), interfaceList, nil
 }
 
-func parsePackages(fromTypeList []argFromType) ([]*astpkg.Package, error) {
-	packagePathList := lo.Uniq(
-		lo.Map(fromTypeList, func(item argFromType, _ int) string {
-			return item.Package
-		}),
-	)
+func parsePackages(cache *astpkg.PackageCache, packagePathList []string) ([]*astpkg.Package, error) {
+	packagePathList = lo.Uniq(packagePathList)
 
 	packages := make([]*astpkg.Package, 0, len(packagePathList))
 	for _, pkgPath := range packagePathList {
-		pkg, err := astpkg.ParsePackage(pkgPath)
+		pkg, err := cache.ParsePackage(pkgPath)
 		if err != nil {
 			return nil, fmt.Errorf("parse package(%s): %w", pkgPath, err)
 		}
@@ -247,3 +555,84 @@ func parseFromType(val string) ([]argFromType, error) {
 	list = lo.Uniq(list)
 	return list, nil
 }
+
+type argFuncs struct {
+	Package    string
+	TargetName string
+}
+
+func parseFuncs(val string) ([]argFuncs, error) {
+	list := make([]argFuncs, 0)
+
+	for _, part := range strings.Split(val, ",") {
+		part = strings.TrimSpace(part)
+
+		packageName, targetName, ok := strings.Cut(part, "=")
+		if !ok || packageName == "" || targetName == "" {
+			return nil, fmt.Errorf("invalid functions package: %s", part)
+		}
+
+		list = append(list, argFuncs{
+			Package:    packageName,
+			TargetName: targetName,
+		})
+	}
+
+	return lo.Uniq(list), nil
+}
+
+// methodSet is the method set of the source type collected to the interface: the method set of the value
+// (the methods of the value receiver), the method set of the pointer (the methods of the value and
+// the pointer receivers) or only the methods of the pointer receiver.
+// The empty method set collects all methods without the assertion.
+type methodSet string
+
+const (
+	methodSetValue       methodSet = "value"
+	methodSetPointer     methodSet = "pointer"
+	methodSetPointerOnly methodSet = "pointer-only"
+)
+
+var methodSetList = []methodSet{methodSetValue, methodSetPointer, methodSetPointerOnly}
+
+func parseMethodSet(val string) (methodSet, error) {
+	if val != "" && !slices.Contains(methodSetList, methodSet(val)) {
+		return "", fmt.Errorf("unknown method set %q, supported: %v", val, methodSetList)
+	}
+
+	return methodSet(val), nil
+}
+
+func (s methodSet) match(decl *astpkg.FuncDecl) bool {
+	switch s {
+	case methodSetValue:
+		return !decl.PointerReceiver
+	case methodSetPointerOnly:
+		return decl.PointerReceiver
+	default:
+		return true
+	}
+}
+
+// funcFilter matches the names of the package functions by the prefix and the regular expression.
+type funcFilter struct {
+	prefix string
+	regex  *regexp.Regexp
+}
+
+func newFuncFilter(prefix, regex string) (funcFilter, error) {
+	filter := funcFilter{prefix: prefix}
+	if regex != "" {
+		var err error
+		filter.regex, err = regexp.Compile(regex)
+		if err != nil {
+			return funcFilter{}, fmt.Errorf("compile functions regex: %w", err)
+		}
+	}
+
+	return filter, nil
+}
+
+func (f funcFilter) match(name string) bool {
+	return strings.HasPrefix(name, f.prefix) && (f.regex == nil || f.regex.MatchString(name))
+}
@@ -1,13 +1,20 @@
 package interface_creator
 
 import (
+	"bytes"
 	"io"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+	"github.com/khevse/codegen/internal/pkg/filterpkg"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
 
@@ -85,6 +92,40 @@ func TestSplitFromType(t *testing.T) {
 func TestPrepareObjectSpecList(t *testing.T) {
 	t.Parallel()
 
+	childStructType := &astpkg.StructType{
+		Fields: []*astpkg.Field{{Name: "FieldString", Type: &astpkg.Ident{Name: "string"}}},
+	}
+	childInterfaceType := &astpkg.InterfaceType{
+		Methods: []*astpkg.Field{
+			{
+				Name: "",
+				Type: &astpkg.SelectorExpr{
+					Package:     "fmt",
+					PackagePath: "fmt",
+					Name:        "Stringer",
+					Type: &astpkg.InterfaceType{
+						Methods: []*astpkg.Field{
+							{
+								Name: "String",
+								Type: &astpkg.FuncType{
+									Params:  []*astpkg.Field{},
+									Results: []*astpkg.Field{{Name: "", Type: &astpkg.Ident{Name: "string"}}},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "OtherMethod",
+				Type: &astpkg.FuncType{
+					Params:  []*astpkg.Field{},
+					Results: []*astpkg.Field{{Name: "", Type: &astpkg.Ident{Name: "any"}}},
+				},
+			},
+		},
+	}
+
 	t.Run("success to self package", func(t *testing.T) {
 		args := commandArgs{
 			fromType:   "github.com/khevse/codegen/tests/mainpkg.StructWithMethods",
@@ -120,7 +161,7 @@ func TestPrepareObjectSpecList(t *testing.T) {
 										Package:     "childpkg",
 										PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 										Name:        "Struct",
-										Type:        nil,
+										Type:        childStructType,
 									},
 								},
 							},
@@ -153,7 +194,7 @@ func TestPrepareObjectSpecList(t *testing.T) {
 										Package:     "childpkg",
 										PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 										Name:        "Struct",
-										Type:        nil,
+										Type:        childStructType,
 									},
 								},
 							},
@@ -208,7 +249,7 @@ func TestPrepareObjectSpecList(t *testing.T) {
 										Package:     "childpkg",
 										PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 										Name:        "Interface",
-										Type:        nil,
+										Type:        childInterfaceType,
 									},
 								},
 							},
@@ -260,7 +301,7 @@ func TestPrepareObjectSpecList(t *testing.T) {
 										Package:     "childpkg",
 										PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 										Name:        "Struct",
-										Type:        nil,
+										Type:        childStructType,
 									},
 								},
 							},
@@ -293,7 +334,7 @@ func TestPrepareObjectSpecList(t *testing.T) {
 										Package:     "childpkg",
 										PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 										Name:        "Struct",
-										Type:        nil,
+										Type:        childStructType,
 									},
 								},
 							},
@@ -348,7 +389,7 @@ func TestPrepareObjectSpecList(t *testing.T) {
 										Package:     "childpkg",
 										PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 										Name:        "Interface",
-										Type:        nil,
+										Type:        childInterfaceType,
 									},
 								},
 							},
@@ -408,3 +449,660 @@ type IStructWithMethods interface {
 		string(data),
 	)
 }
+
+func TestExecuteGenericType(t *testing.T) {
+	args := commandArgs{
+		fromType:   "github.com/khevse/codegen/tests/mainpkg.Cache=ICache",
+		targetDir:  "./",
+		fileSuffix: "_generic_generated",
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "interfaces_generic_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package interface_creator
+
+import (
+	childpkg "github.com/khevse/codegen/tests/mainpkg/childpkg"
+)
+
+/* ICache interface for type Cache: Cache comment */
+type ICache[K comparable, V any] interface {
+	/* Get comment */
+	Get(key K) (_ V, _ bool)
+	Pair(key K) (_ childpkg.Pair[K, V])
+	Set(key K, val V)
+}
+`,
+		string(data),
+	)
+}
+
+func TestExecutePromotedMethods(t *testing.T) {
+	args := commandArgs{
+		fromType:   "github.com/khevse/codegen/tests/mainpkg.Service=IService",
+		targetDir:  "./",
+		fileSuffix: "_promoted_generated",
+		promoted:   true,
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "interfaces_promoted_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package interface_creator
+
+import ()
+
+/* IService interface for type Service: Service comment */
+type IService interface {
+	/* Close comment (promoted from BaseService) */
+	Close() (_ error)
+	/* promoted from Struct */
+	GetFieldString() (_ string)
+	/* promoted from BaseService.Mutex */
+	Lock()
+	/* Name comment */
+	Name() (_ string)
+	/* promoted from IObject1 */
+	String() (_ string)
+	/* promoted from BaseService.Mutex */
+	TryLock() (_ bool)
+	/* promoted from BaseService.Mutex */
+	Unlock()
+}
+`,
+		string(data),
+	)
+}
+
+func TestExecuteCheck(t *testing.T) {
+	args := commandArgs{
+		fromType:   "github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStructWithMethods",
+		targetDir:  "./",
+		fileSuffix: "_check_generated",
+		check:      true,
+	}
+
+	const wantFile = "interfaces_check_generated.go"
+
+	err := (&Command{args: args}).Execute()
+	require.ErrorIs(t, err, filepkg.ErrOutdated)
+	require.NoFileExists(t, wantFile)
+
+	args.check = false
+	require.NoError(t, (&Command{args: args}).Execute())
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	args.check = true
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	f, err := os.OpenFile(wantFile, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("// changed\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	err = (&Command{args: args}).Execute()
+	require.ErrorIs(t, err, filepkg.ErrOutdated)
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(string(data), "// changed\n"))
+}
+
+func TestExecuteDryRun(t *testing.T) {
+	args := commandArgs{
+		fromType:   "github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStructWithMethods",
+		targetDir:  "./",
+		fileSuffix: "_dry_run_generated",
+		dryRun:     true,
+	}
+
+	require.NoError(t, (&Command{args: args}).Execute())
+	require.NoFileExists(t, "interfaces_dry_run_generated.go")
+}
+
+func TestExecuteOutput(t *testing.T) {
+	args := commandArgs{
+		fromType:   "github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStructWithMethods",
+		targetDir:  "./",
+		fileSuffix: "_output_generated",
+		output:     "-",
+	}
+
+	require.NoError(t, (&Command{args: args}).Execute())
+	require.NoFileExists(t, "interfaces_output_generated.go")
+
+	args.output = "interfaces.go"
+	require.EqualError(t, (&Command{args: args}).Execute(), `new writer: unsupported output "interfaces.go", only "-" is supported`)
+
+	args.output = "-"
+	args.check = true
+	require.EqualError(t, (&Command{args: args}).Execute(), "new writer: check, dry run and output modes can not be combined")
+}
+
+func TestExecuteCustomTemplate(t *testing.T) {
+	templateFile := filepath.Join(t.TempDir(), "interfaces.tmpl")
+	require.NoError(t, os.WriteFile(templateFile, []byte(`//go:build tools
+
+package {{ .package }}
+{{ range .interfaces }}
+// {{ .Name }} methods:
+{{- range .Methods }}
+//   - {{ .Name }}
+{{- end }}
+{{- end }}
+`), 0o644))
+
+	args := commandArgs{
+		fromType:     "github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStructWithMethods",
+		targetDir:    "./",
+		fileSuffix:   "_template_generated",
+		templateFile: templateFile,
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "interfaces_template_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`//go:build tools
+
+package interface_creator
+
+// IStructWithMethods methods:
+//   - GetFieldString
+//   - GetFieldStruct
+//   - SetAllFields
+//   - SetFieldStringFromInterface
+//   - SetFieldStruct
+`,
+		string(data),
+	)
+}
+
+func TestExecuteFuncs(t *testing.T) {
+	args := commandArgs{
+		funcs:      "github.com/khevse/codegen/tests/funcspkg=IUserStorage",
+		targetDir:  "./",
+		fileSuffix: "_funcs_generated",
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "interfaces_funcs_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package interface_creator
+
+import (
+	context "context"
+	funcspkg "github.com/khevse/codegen/tests/funcspkg"
+	io "io"
+)
+
+/* IUserStorage interface for functions of package github.com/khevse/codegen/tests/funcspkg */
+type IUserStorage interface {
+	/* Export writes the users */
+	Export(w io.Writer, users []*funcspkg.User) (n int, err error)
+	/* FetchUser returns the user by the identifier */
+	FetchUser(ctx context.Context, id int) (_ *funcspkg.User, _ error)
+	/* FetchUsers returns the users by the identifiers */
+	FetchUsers(ctx context.Context, ids ...int) (_ []*funcspkg.User, _ error)
+	/* Lookup returns the value by the key, the arguments clash with the package name and the generated names */
+	Lookup(funcspkg string, _ int, p1 bool) (context string, err error)
+	StoreUser(_ context.Context, _ *funcspkg.User) (_ error)
+}
+
+/* IUserStorageImpl implements IUserStorage by the package functions. */
+type IUserStorageImpl struct{}
+
+var _ IUserStorage = IUserStorageImpl{}
+
+func (IUserStorageImpl) Export(w io.Writer, users []*funcspkg.User) (n int, err error) {
+	return funcspkg.Export(w, users)
+}
+
+func (IUserStorageImpl) FetchUser(ctx context.Context, id int) (_ *funcspkg.User, _ error) {
+	return funcspkg.FetchUser(ctx, id)
+}
+
+func (IUserStorageImpl) FetchUsers(ctx context.Context, ids ...int) (_ []*funcspkg.User, _ error) {
+	return funcspkg.FetchUsers(ctx, ids...)
+}
+
+func (IUserStorageImpl) Lookup(p0 string, p2 int, p1 bool) (r0 string, err error) {
+	return funcspkg.Lookup(p0, p2, p1)
+}
+
+func (IUserStorageImpl) StoreUser(p0 context.Context, p1 *funcspkg.User) (_ error) {
+	return funcspkg.StoreUser(p0, p1)
+}
+`,
+		string(data),
+	)
+}
+
+func TestPrepareObjectSpecListFuncs(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Funcs       string
+		FuncPrefix  string
+		FuncRegex   string
+		WantMethods []string
+		WantErr     string
+	}{
+		"prefix": {
+			Funcs:       "github.com/khevse/codegen/tests/funcspkg=IUsers",
+			FuncPrefix:  "Fetch",
+			WantMethods: []string{"FetchUser", "FetchUsers"},
+		},
+		"prefix and regex": {
+			Funcs:       "github.com/khevse/codegen/tests/funcspkg=IUsers",
+			FuncPrefix:  "Fetch",
+			FuncRegex:   "User$",
+			WantMethods: []string{"FetchUser"},
+		},
+		"not found": {
+			Funcs:      "github.com/khevse/codegen/tests/funcspkg=IUsers",
+			FuncPrefix: "Load",
+			WantErr:    "functions are not found in package: github.com/khevse/codegen/tests/funcspkg",
+		},
+		"invalid regex": {
+			Funcs:     "github.com/khevse/codegen/tests/funcspkg=IUsers",
+			FuncRegex: "(",
+			WantErr:   "compile functions regex: error parsing regexp: missing closing ): `(`",
+		},
+		"without interface name": {
+			Funcs:   "github.com/khevse/codegen/tests/funcspkg",
+			WantErr: "parse functions packages: invalid functions package: github.com/khevse/codegen/tests/funcspkg",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, list, err := prepareObjectSpecList(commandArgs{
+				funcs:      tc.Funcs,
+				funcPrefix: tc.FuncPrefix,
+				funcRegex:  tc.FuncRegex,
+				targetDir:  "./",
+			})
+			if tc.WantErr != "" {
+				require.EqualError(t, err, tc.WantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			require.Equal(t, "IUsersImpl", list[0].Impl.Name)
+			require.Equal(t, "funcspkg.", list[0].Impl.Qualifier)
+			require.Equal(t, list[0].Methods, list[0].Impl.Methods)
+			require.Equal(t, tc.WantMethods, lo.Map(list[0].Methods, func(item methodSpec, _ int) string {
+				return item.Name
+			}))
+		})
+	}
+}
+
+func TestExecuteFilter(t *testing.T) {
+	args := commandArgs{
+		fromType:   "github.com/khevse/codegen/tests/filteredpkg.Account=IAccount",
+		targetDir:  "./",
+		fileSuffix: "_filter_generated",
+		filter: filterpkg.Params{
+			Exclude:      []string{"Account.SetBalance"},
+			ExcludeRegex: []string{`^Account\.Own`},
+		},
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "interfaces_filter_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package interface_creator
+
+import ()
+
+/* IAccount interface for type Account: Account comment */
+type IAccount interface {
+	/* Balance comment */
+	Balance() (_ int)
+	/* ID comment */
+	ID() (_ string)
+}
+`,
+		string(data),
+	)
+}
+
+func TestExecuteFilterMatchesNothing(t *testing.T) {
+	for name, tc := range map[string]struct {
+		FromType  string
+		MethodSet string
+		Filter    filterpkg.Params
+		WantErr   string
+	}{
+		"unknown method": {
+			FromType: "github.com/khevse/codegen/tests/filteredpkg.Account=IAccount",
+			Filter:   filterpkg.Params{Include: []string{"Account.ID", "Store.Get"}},
+			WantErr:  "include rule matches nothing: Store.Get",
+		},
+		"method outside the method set": {
+			FromType:  "github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStruct",
+			MethodSet: "value",
+			Filter:    filterpkg.Params{Include: []string{"GetFieldString", "SetFieldStruct"}},
+			WantErr:   "include rule matches only the methods outside the method set: SetFieldStruct",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			args := commandArgs{
+				fromType:   tc.FromType,
+				methodSet:  tc.MethodSet,
+				targetDir:  "./",
+				fileSuffix: "_matches_nothing_generated",
+				filter:     tc.Filter,
+			}
+			require.EqualError(t, (&Command{args: args}).Execute(), tc.WantErr)
+
+			// the file is not written
+			_, err := os.Stat("interfaces_matches_nothing_generated.go")
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func TestExecuteMethodSet(t *testing.T) {
+	args := commandArgs{
+		fromType: "github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStructWithMethods," +
+			"github.com/khevse/codegen/tests/mainpkg.Cache=ICache",
+		targetDir:  "./",
+		fileSuffix: "_method_set_generated",
+		methodSet:  "value",
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "interfaces_method_set_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package interface_creator
+
+import (
+	mainpkg "github.com/khevse/codegen/tests/mainpkg"
+	childpkg "github.com/khevse/codegen/tests/mainpkg/childpkg"
+)
+
+/* ICache interface for type Cache: Cache comment */
+type ICache[K comparable, V any] interface {
+	Pair(key K) (_ childpkg.Pair[K, V])
+}
+
+func _[K comparable, V any]() {
+	var _ ICache[K, V] = mainpkg.Cache[K, V]{}
+}
+
+/* IStructWithMethods interface for type StructWithMethods: StructWithMethods comment */
+type IStructWithMethods interface {
+	GetFieldString() (_ string)
+	/* GetFieldStruct comment */
+	GetFieldStruct() (_ childpkg.Struct)
+}
+
+var _ IStructWithMethods = mainpkg.StructWithMethods{}
+`,
+		string(data),
+	)
+}
+
+func TestPrepareObjectSpecListMethodSet(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		FromType      string
+		MethodSet     string
+		Promoted      bool
+		WantMethods   []string
+		WantAssertion *assertionSpec
+		WantErr       string
+	}{
+		"all": {
+			FromType:    "github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStruct",
+			WantMethods: []string{"GetFieldStruct", "GetFieldString", "SetFieldStruct", "SetAllFields", "SetFieldStringFromInterface"},
+		},
+		"value": {
+			FromType:    "github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStruct",
+			MethodSet:   "value",
+			WantMethods: []string{"GetFieldStruct", "GetFieldString"},
+			WantAssertion: &assertionSpec{
+				Interface: "IStruct",
+				Value:     "mainpkg.StructWithMethods{}",
+				Qualifier: "mainpkg.",
+			},
+		},
+		"pointer": {
+			FromType:  "github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStruct",
+			MethodSet: "pointer",
+			// the method set of the pointer includes the methods of the value receiver
+			WantMethods: []string{"GetFieldStruct", "GetFieldString", "SetFieldStruct", "SetAllFields", "SetFieldStringFromInterface"},
+			WantAssertion: &assertionSpec{
+				Interface: "IStruct",
+				Value:     "(*mainpkg.StructWithMethods)(nil)",
+				Qualifier: "mainpkg.",
+			},
+		},
+		"pointer only": {
+			FromType:    "github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStruct",
+			MethodSet:   "pointer-only",
+			WantMethods: []string{"SetFieldStruct", "SetAllFields", "SetFieldStringFromInterface"},
+			WantAssertion: &assertionSpec{
+				Interface: "IStruct",
+				Value:     "(*mainpkg.StructWithMethods)(nil)",
+				Qualifier: "mainpkg.",
+			},
+		},
+		"value of promoted": {
+			FromType:  "github.com/khevse/codegen/tests/mainpkg.Service=IService",
+			MethodSet: "value",
+			Promoted:  true,
+			// the methods of sync.Mutex embedded to *BaseService are in the method set of the value
+			WantMethods: []string{"Name", "Close", "GetFieldString", "Lock", "String", "TryLock", "Unlock"},
+			WantAssertion: &assertionSpec{
+				Interface: "IService",
+				Value:     "mainpkg.Service{}",
+				Qualifier: "mainpkg.",
+			},
+		},
+		"unknown": {
+			FromType:  "github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStruct",
+			MethodSet: "interface",
+			WantErr:   `unknown method set "interface", supported: [value pointer pointer-only]`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, list, err := prepareObjectSpecList(commandArgs{
+				fromType:  tc.FromType,
+				methodSet: tc.MethodSet,
+				promoted:  tc.Promoted,
+				targetDir: "./",
+			})
+			if tc.WantErr != "" {
+				require.EqualError(t, err, tc.WantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			require.Equal(t, tc.WantAssertion, list[0].Assertion)
+			require.Equal(t, tc.WantMethods, lo.Map(list[0].Methods, func(item methodSpec, _ int) string {
+				return item.Name
+			}))
+		})
+	}
+}
+
+func TestNewSpecAssert(t *testing.T) {
+	t.Parallel()
+
+	spec, err := newSpec(commandArgs{
+		fromType: "github.com/khevse/codegen/tests/mainpkg.StructWithMethods=IStructWithMethods," +
+			"github.com/khevse/codegen/tests/mainpkg.Cache=ICache," +
+			"github.com/khevse/codegen/tests/filteredpkg.Account=IAccount," +
+			"github.com/khevse/codegen/tests/filteredpkg.Ledger=ILedger",
+		funcs:     "github.com/khevse/codegen/tests/funcspkg=IUserStorage",
+		targetDir: "./",
+		assert:    true,
+		methodFilter: lo.Must(filterpkg.NewMethodFilter(filterpkg.Params{
+			Exclude: []string{"Account.Owner"},
+		})),
+	})
+	require.NoError(t, err)
+
+	_, file, _, _ := runtime.Caller(0)
+	testsDir := filepath.Join(filepath.Dir(file), "../../../tests")
+
+	got := lo.Map(spec.Asserts, func(item *AssertSpec, _ int) [2]string {
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, item.Generate(buf))
+		return [2]string{item.FilePath, buf.String()}
+	})
+	require.Equal(
+		t,
+		[][2]string{
+			{
+				// the interfaces do not import the package: the assertions are in the package
+				filepath.Join(testsDir, "filteredpkg/interface_creator_interfaces_assert.go"),
+				`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package filteredpkg
+
+import (
+	cmp "cmp"
+	interface_creator "github.com/khevse/codegen/internal/command/interface_creator"
+)
+
+var _ interface_creator.IAccount = (*Account)(nil)
+
+func _[K cmp.Ordered, V any]() {
+	var _ interface_creator.ILedger[K, V] = (*Ledger[K, V])(nil)
+}
+`,
+			},
+			{
+				// the interfaces import the package: the assertions are in the external test package
+				filepath.Join(testsDir, "mainpkg/interface_creator_interfaces_assert_test.go"),
+				`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package mainpkg_test
+
+import (
+	interface_creator "github.com/khevse/codegen/internal/command/interface_creator"
+	mainpkg "github.com/khevse/codegen/tests/mainpkg"
+)
+
+func _[K comparable, V any]() {
+	var _ interface_creator.ICache[K, V] = (*mainpkg.Cache[K, V])(nil)
+}
+
+var _ interface_creator.IStructWithMethods = (*mainpkg.StructWithMethods)(nil)
+`,
+			},
+		},
+		got,
+	)
+}
+
+func TestGetAnnotatedArgsList(t *testing.T) {
+	t.Parallel()
+
+	pkgDir, err := filepath.Abs("./../../../tests/annotatedpkg")
+	require.NoError(t, err)
+
+	args := commandArgs{
+		fileSuffix: "_generated",
+		annotated:  "github.com/khevse/codegen/tests/...",
+	}
+
+	res, err := getAnnotatedArgsList(args)
+	require.NoError(t, err)
+	require.Equal(t,
+		[]commandArgs{
+			{
+				fromType: "github.com/khevse/codegen/tests/annotatedpkg.Store=IStore," +
+					"github.com/khevse/codegen/tests/annotatedpkg.Counter=ICounter",
+				targetDir:  pkgDir,
+				fileSuffix: "_generated",
+			},
+		},
+		res,
+	)
+}
+
+func TestExecuteAnnotated(t *testing.T) {
+	t.Parallel()
+
+	args := commandArgs{
+		check:     true,
+		annotated: "github.com/khevse/codegen/tests/annotatedpkg",
+	}
+
+	// the files are not generated yet, the check mode does not write them
+	err := (&Command{args: args}).Execute()
+	require.ErrorIs(t, err, filepkg.ErrOutdated)
+	require.ErrorContains(t, err, filepath.Join("tests", "annotatedpkg", "interfaces.go"))
+	require.NoFileExists(t, "./../../../tests/annotatedpkg/interfaces.go")
+
+	t.Run("required flags", func(t *testing.T) {
+		err := (&Command{args: commandArgs{}}).Execute()
+		require.EqualError(t, err, "flags --type or --funcs and --target-dir are required without --annotated")
+	})
+}
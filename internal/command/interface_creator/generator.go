@@ -11,13 +11,25 @@ import (
 	"github.com/khevse/codegen/internal/pkg/templatepkg"
 )
 
-//go:embed file.tmpl
+const (
+	templateFile       = "file.tmpl"
+	assertTemplateFile = "assert.tmpl"
+)
+
+//go:embed file.tmpl assert.tmpl
 var content embed.FS
 
+// defaultTemplate returns the embedded template.
+func defaultTemplate() ([]byte, error) {
+	return content.ReadFile(templateFile)
+}
+
 type generator struct {
 	Package    string
 	Imports    astpkg.ImportList
 	Interfaces []objectSpec
+	// Template is the path of the custom template file, optional
+	Template string
 }
 
 func (g generator) Generate(w io.Writer) error {
@@ -27,20 +39,58 @@ func (g generator) Generate(w io.Writer) error {
 	slices.SortFunc(g.Interfaces, func(i, j objectSpec) int {
 		return strings.Compare(i.Name, j.Name)
 	})
-	for _, item := range g.Interfaces {
-		slices.SortFunc(item.Methods, func(i, j methodSpec) int {
+	sortMethods := func(list []methodSpec) {
+		slices.SortFunc(list, func(i, j methodSpec) int {
 			return strings.Compare(i.Name, j.Name)
 		})
 	}
+	for _, item := range g.Interfaces {
+		sortMethods(item.Methods)
+		if item.Impl != nil {
+			sortMethods(item.Impl.Methods)
+		}
+	}
+
+	params := templatepkg.ExecuteTemplateParams{
+		Writer:             w,
+		FS:                 content,
+		TemplateFile:       templateFile,
+		CustomTemplateFile: g.Template,
+		Data: map[string]any{
+			"package":    g.Package,
+			"imports":    g.Imports,
+			"interfaces": g.Interfaces,
+			"appInfo":    application.GetInfo(),
+		},
+		Format: true,
+	}
+
+	return templatepkg.ExecuteTemplate(params)
+}
+
+// assertGenerator generates the file with the assertions of the types in the source package.
+type assertGenerator struct {
+	Package    string
+	Imports    astpkg.ImportList
+	Assertions []assertionSpec
+}
+
+func (g assertGenerator) Generate(w io.Writer) error {
+	slices.SortFunc(g.Imports, func(i, j astpkg.Import) int {
+		return strings.Compare(i.Path, j.Path)
+	})
+	slices.SortFunc(g.Assertions, func(i, j assertionSpec) int {
+		return strings.Compare(i.Interface, j.Interface)
+	})
 
 	params := templatepkg.ExecuteTemplateParams{
 		Writer:       w,
 		FS:           content,
-		TemplateFile: "file.tmpl",
+		TemplateFile: assertTemplateFile,
 		Data: map[string]any{
 			"package":    g.Package,
 			"imports":    g.Imports,
-			"interfaces": g.Interfaces,
+			"assertions": g.Assertions,
 			"appInfo":    application.GetInfo(),
 		},
 		Format: true,
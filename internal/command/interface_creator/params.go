@@ -0,0 +1,119 @@
+package interface_creator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/filterpkg"
+)
+
+// Params are the parameters of the interfaces generation without the command line.
+type Params struct {
+	// Types are the source types: <package>.<TypeName> or <package>.<TypeName>=<InterfaceName>
+	Types []string
+	// Funcs are the packages with the exported functions: <package>=<InterfaceName>
+	Funcs []string
+	// FuncPrefix is the prefix of the names of the collected functions, optional
+	FuncPrefix string
+	// FuncRegex is the regular expression matching the names of the collected functions, optional
+	FuncRegex string
+	// TargetDir is the dir of the generated file
+	TargetDir string
+	// FileSuffix is the suffix of the generated file name: interfaces<suffix>.go
+	FileSuffix string
+	// Filter are the include and exclude rules of the methods, optional
+	Filter filterpkg.Params
+	// Promoted includes the methods promoted from the embedded fields
+	Promoted bool
+	// MethodSet is the method set of the types: value, pointer or pointer-only, the assertion is generated for the selected set, optional
+	MethodSet string
+	// Assert generates the assertions of the types to the source packages, see Spec.Asserts
+	Assert bool
+	// Template is the path of the custom template file, optional
+	Template string
+	// Cache is the cache of the parsed packages, optional
+	Cache *astpkg.PackageCache
+}
+
+// Spec is the specification of the generated file: the data of the template and the file path.
+type Spec struct {
+	FilePath string
+	generator
+	// Asserts are the files with the assertions of the types in the source packages, one file per package.
+	Asserts []*AssertSpec
+}
+
+// NewSpec parses the packages of the types and builds the specification of the interfaces.
+// The rules of the filter which have not matched any method are returned as the error.
+func NewSpec(params Params) (*Spec, error) {
+	methodFilter, err := filterpkg.NewMethodFilter(params.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("new method filter: %w", err)
+	}
+
+	spec, err := newSpec(commandArgs{
+		fromType:     strings.Join(params.Types, ","),
+		funcs:        strings.Join(params.Funcs, ","),
+		funcPrefix:   params.FuncPrefix,
+		funcRegex:    params.FuncRegex,
+		targetDir:    params.TargetDir,
+		fileSuffix:   params.FileSuffix,
+		promoted:     params.Promoted,
+		methodSet:    params.MethodSet,
+		assert:       params.Assert,
+		templateFile: params.Template,
+		methodFilter: methodFilter,
+		packageCache: params.Cache,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := methodFilter.Err(); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+func newSpec(args commandArgs) (*Spec, error) {
+	targetDir, err := filepath.Abs(args.targetDir)
+	if err != nil {
+		return nil, fmt.Errorf("get target dir full path: %w", err)
+	}
+
+	importList, objectSpecList, err := prepareObjectSpecList(args)
+	if err != nil {
+		return nil, fmt.Errorf("prepare objects specifications: %w", err)
+	}
+
+	fileName := fmt.Sprintf("interfaces%s.go", args.fileSuffix)
+	packageName := filepath.Base(targetDir)
+
+	var asserts []*AssertSpec
+	if args.assert {
+		asserts, err = newAssertSpecList(newAssertSpecListParams{
+			targetDir:   targetDir,
+			packageName: packageName,
+			fileSuffix:  args.fileSuffix,
+			imports:     importList,
+			interfaces:  objectSpecList,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("new assertions specifications: %w", err)
+		}
+	}
+
+	return &Spec{
+		FilePath: filepath.Join(targetDir, fileName),
+		generator: generator{
+			Package:    packageName,
+			Imports:    importList,
+			Interfaces: objectSpecList,
+			Template:   args.templateFile,
+		},
+		Asserts: asserts,
+	}, nil
+}
@@ -3,6 +3,8 @@ package interface_creator
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/khevse/codegen/internal/pkg/astpkg"
 	"github.com/samber/lo"
@@ -21,12 +23,62 @@ type methodSpec struct {
 	Results []field
 }
 
+// CallArgs returns the arguments of the method call: ctx, ids...
+func (m methodSpec) CallArgs() string {
+	return strings.Join(lo.Map(m.Params, func(item field, _ int) string {
+		if _, ok := item.Type.(*astpkg.EllipsisType); ok {
+			return item.Name + "..."
+		}
+		return item.Name
+	}), ", ")
+}
+
 type objectSpec struct {
-	Name    string
-	Comment string
+	Name       string
+	TypeParams []field
+	Comment    string
+	Methods    []methodSpec
+	// Impl is the default implementation of the interface generated from the package functions.
+	Impl *implSpec
+	// Assertion is the compile-time assertion of the source type, it is set if the method set is selected.
+	Assertion *assertionSpec
+	// Source is the source type of the assertions in the source package, it is set with --assert.
+	Source *sourceSpec
+}
+
+// implSpec is the struct implementing the interface by the package functions.
+type implSpec struct {
+	Name string
+	// Qualifier is the qualifier of the functions in the generated code: pkg. or empty for the target package.
+	Qualifier string
+	// Methods are the methods of the interface with the arguments renamed for the function call.
 	Methods []methodSpec
 }
 
+// assertionSpec is the assertion that the source type implements the interface: var _ IName = (*T)(nil).
+type assertionSpec struct {
+	// TypeParams are the type parameters of the generic assertion function: [K comparable, V any].
+	TypeParams string
+	// Interface is the instantiated interface: ICache[K, V].
+	Interface string
+	// Value is the value of the source type: (*pkg.Cache[K, V])(nil) or pkg.Cache[K, V]{}.
+	Value string
+	// Qualifier is the qualifier of the source type: pkg. or empty for the target package.
+	Qualifier string
+}
+
+func newAssertionSpec(name string, typeDecl *astpkg.TypeDecl, qualifier string, set methodSet) *assertionSpec {
+	typeArgs := astpkg.TypeParamsArgs(typeDecl.TypeParams)
+	typeName := qualifier + typeDecl.Name + typeArgs
+
+	return &assertionSpec{
+		TypeParams: astpkg.TypeParamsDecl(typeDecl.TypeParams),
+		Interface:  name + typeArgs,
+		Value:      lo.Ternary(set == methodSetValue, typeName+"{}", fmt.Sprintf("(*%s)(nil)", typeName)),
+		Qualifier:  qualifier,
+	}
+}
+
 func newObjectSpec(
 	name string,
 	typeDecl *astpkg.TypeDecl,
@@ -44,6 +96,16 @@ func newObjectSpec(
 		if err != nil {
 			return objectSpec{}, fmt.Errorf("replace imports(%s): %w", decl, err)
 		}
+
+		if err := renameReceiverTypeParams(typeDecl, decl); err != nil {
+			return objectSpec{}, fmt.Errorf("rename receiver type params(%s): %w", decl, err)
+		}
+	}
+
+	for _, item := range typeDecl.TypeParams {
+		if err := astpkg.ReplaceImportAliasByImportPath(item.Type, imports); err != nil {
+			return objectSpec{}, fmt.Errorf("replace type param imports(%s): %w", item.Name, err)
+		}
 	}
 
 	methodList := make([]methodSpec, 0, len(methods))
@@ -57,7 +119,7 @@ func newObjectSpec(
 
 		method := methodSpec{
 			Name:    item.Name,
-			Comment: item.Comment,
+			Comment: newMethodComment(item),
 			Params:  params,
 			Results: results,
 		}
@@ -65,13 +127,170 @@ func newObjectSpec(
 		methodList = append(methodList, method)
 	}
 
+	return objectSpec{
+		Name:       name,
+		TypeParams: newTypeParamsList(typeDecl.TypeParams),
+		Comment:    fmt.Sprintf("%s interface for type %s: %s", name, typeDecl.Name, typeDecl.Comment),
+		Methods:    methodList,
+	}, nil
+}
+
+// newFuncsSpec returns the specification of the interface with the methods from the package
+// functions and the default implementation calling the functions.
+func newFuncsSpec(
+	name string,
+	pkg *astpkg.Package,
+	funcs []*astpkg.FuncDecl,
+	imports astpkg.ImportList,
+	targetPackage string,
+) (objectSpec, error) {
+	for _, decl := range funcs {
+		err := astpkg.InspectFuncDeclFields(decl, func(f *astpkg.Field) error {
+			return astpkg.ReplaceImportAliasByImportPath(f.Type, imports)
+		})
+		if err != nil {
+			return objectSpec{}, fmt.Errorf("replace imports(%s): %w", decl, err)
+		}
+	}
+
+	qualifier, err := packageQualifier(pkg.Path, targetPackage, imports)
+	if err != nil {
+		return objectSpec{}, fmt.Errorf("get functions package: %w", err)
+	}
+
+	// the arguments of the implementation must not shadow the packages used by the function call
+	aliases := lo.FilterMap(imports, func(item astpkg.Import, _ int) (string, bool) {
+		return item.Alias, item.Alias != ""
+	})
+
+	methodList := lo.Map(funcs, func(item *astpkg.FuncDecl, _ int) methodSpec {
+		return methodSpec{
+			Name:    item.Name,
+			Comment: item.Comment,
+			Params:  newFieldsList(item.Params),
+			Results: newFieldsList(item.Results),
+		}
+	})
+
 	return objectSpec{
 		Name:    name,
-		Comment: fmt.Sprintf("%s interface for type %s: %s", name, typeDecl.Name, typeDecl.Comment),
+		Comment: fmt.Sprintf("%s interface for functions of package %s", name, pkg.Path),
 		Methods: methodList,
+		Impl: &implSpec{
+			Name:      name + "Impl",
+			Qualifier: qualifier,
+			Methods: lo.Map(methodList, func(item methodSpec, _ int) methodSpec {
+				return renameImplArgs(item, aliases)
+			}),
+		},
 	}, nil
 }
 
+// renameImplArgs returns the method of the implementation with the arguments renamed if they clash with
+// the reserved names, the parameters are passed to the function, so the unnamed parameters are named too.
+// The new names are p<n> or r<n> starting from the argument index, the names used by the method are skipped.
+func renameImplArgs(method methodSpec, reserved []string) methodSpec {
+	used := slices.Clone(reserved)
+	for _, item := range slices.Concat(method.Params, method.Results) {
+		if item.Name != "_" && !slices.Contains(reserved, item.Name) {
+			used = append(used, item.Name)
+		}
+	}
+
+	rename := func(fieldList []field, prefix string, renameUnnamed bool) []field {
+		res := slices.Clone(fieldList)
+		for i := range res {
+			name := res[i].Name
+			if !(renameUnnamed && name == "_") && !slices.Contains(reserved, name) {
+				continue
+			}
+
+			for n := i; ; n++ {
+				name = fmt.Sprintf("%s%d", prefix, n)
+				if !slices.Contains(used, name) {
+					break
+				}
+			}
+			used = append(used, name)
+			res[i].Name = name
+		}
+
+		return res
+	}
+
+	method.Params = rename(method.Params, "p", true)
+	method.Results = rename(method.Results, "r", false)
+
+	return method
+}
+
+// packageQualifier returns the qualifier of the package declarations in the generated code:
+// pkg. or empty for the target package.
+func packageQualifier(pkgPath, targetPackage string, imports astpkg.ImportList) (string, error) {
+	if pkgPath == targetPackage {
+		return "", nil
+	}
+
+	imp, ok := imports.GetByPath(pkgPath)
+	if !ok {
+		return "", fmt.Errorf("not found package import: %s", pkgPath)
+	}
+
+	return imp.Alias + ".", nil
+}
+
+// renameReceiverTypeParams aligns the type parameters names of the method receiver
+// with the names from the type declaration: func (c *Cache[A, B]) -> Cache[K, V].
+func renameReceiverTypeParams(typeDecl *astpkg.TypeDecl, decl *astpkg.FuncDecl) error {
+	if len(decl.ReceiverTypeParams) != len(typeDecl.TypeParams) {
+		return fmt.Errorf(
+			"receiver has %d type params, type declaration has %d",
+			len(decl.ReceiverTypeParams), len(typeDecl.TypeParams),
+		)
+	}
+
+	names := make(map[string]string, len(decl.ReceiverTypeParams))
+	for i, name := range decl.ReceiverTypeParams {
+		if declName := typeDecl.TypeParams[i].Name; name != declName && name != "_" {
+			names[name] = declName
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	decl.ReceiverTypeParams = lo.Map(typeDecl.TypeParams, func(item *astpkg.Field, _ int) string {
+		return item.Name
+	})
+
+	return astpkg.InspectFuncDeclFields(decl, func(f *astpkg.Field) error {
+		return astpkg.RenameTypeParams(f.Type, names)
+	})
+}
+
+// newMethodComment adds the source of the promoted method to the method comment.
+func newMethodComment(decl *astpkg.FuncDecl) string {
+	if decl.PromotedFrom == "" {
+		return decl.Comment
+	}
+
+	source := fmt.Sprintf("promoted from %s", decl.PromotedFrom)
+	if decl.Comment == "" {
+		return source
+	}
+
+	return fmt.Sprintf("%s (%s)", decl.Comment, source)
+}
+
+func newTypeParamsList(src []*astpkg.Field) []field {
+	if len(src) == 0 {
+		return nil
+	}
+
+	return newFieldsList(src)
+}
+
 func newFieldsList(src []*astpkg.Field) []field {
 	fieldList := make([]field, 0, len(src))
 	for _, item := range src {
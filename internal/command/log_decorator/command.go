@@ -0,0 +1,252 @@
+package log_decorator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/command"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+	"github.com/khevse/codegen/internal/pkg/filterpkg"
+	"github.com/khevse/codegen/internal/pkg/interfacepkg"
+	"github.com/khevse/codegen/internal/pkg/stringspkg"
+)
+
+type commandArgs struct {
+	interfaceType string
+	targetDir     string
+	fileSuffix    string
+	redact        []string
+	skip          []string
+	check         bool
+	dryRun        bool
+	output        string
+	templateFile  string
+
+	packageCache *astpkg.PackageCache
+}
+
+type Command struct {
+	args commandArgs
+}
+
+func New() *Command {
+	return new(Command)
+}
+
+func (c *Command) Name() string {
+	return "log-decorator"
+}
+
+func (c *Command) ShortName() string {
+	return "ld"
+}
+
+// SetPackageCache sets the cache of the parsed packages shared between the commands.
+func (c *Command) SetPackageCache(cache *astpkg.PackageCache) {
+	c.args.packageCache = cache
+}
+
+// DefaultTemplate returns the default template of the generated file.
+func (c *Command) DefaultTemplate() ([]byte, error) {
+	return defaultTemplate()
+}
+
+func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
+	const (
+		flagInterfaceType = "interface-type"
+		flagTargetDir     = "target-dir"
+		flagFileSuffix    = "suffix"
+		flagRedact        = "redact"
+		flagSkip          = "skip"
+		flagCheck         = "check"
+		flagDryRun        = "dry-run"
+		flagOutput        = "output"
+		flagTemplateFile  = "template"
+	)
+
+	flagSetter.Flags().StringVarP(
+		&c.args.interfaceType,
+		flagInterfaceType,
+		"i",
+		"",
+		"interface type for decorator generation. Examples: <package>.<InterfaceName>; <package>.<InterfaceName>=<DecoratorName>; <package>.<InterfaceName1>,<package>.<InterfaceName2>=<DecoratorName2>",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.targetDir,
+		flagTargetDir,
+		"p",
+		"",
+		"target dir for the decorators",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.fileSuffix,
+		flagFileSuffix,
+		"",
+		"",
+		"result file suffix",
+	)
+	flagSetter.Flags().StringSliceVarP(
+		&c.args.redact,
+		flagRedact,
+		"",
+		nil,
+		"parameters logged as [REDACTED]. Examples: <param>; <Method>.<param>; <param1>,<Method>.<param2>",
+	)
+	flagSetter.Flags().StringSliceVarP(
+		&c.args.skip,
+		flagSkip,
+		"",
+		nil,
+		"methods passed to the base object without logging. Examples: <Method>; <Method1>,<Method2>",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.check,
+		flagCheck,
+		"",
+		false,
+		"check that the generated files are up to date without writing them, the differences are printed",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.dryRun,
+		flagDryRun,
+		"",
+		false,
+		"list the files which would be written or changed without writing them",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.output,
+		flagOutput,
+		"",
+		"",
+		"print the generated code to stdout instead of writing the files, the only supported value is -",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.templateFile,
+		flagTemplateFile,
+		"",
+		"",
+		"custom template file used instead of the default template",
+	)
+
+	for _, flagName := range []string{flagInterfaceType, flagTargetDir} {
+		if err := flagSetter.MarkFlagRequired(flagName); err != nil {
+			return fmt.Errorf("mark flag as required(%s): %w", flagName, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Command) Execute() error {
+	writer, err := filepkg.NewWriter(filepkg.WriterParams{
+		Check:      c.args.check,
+		DryRun:     c.args.dryRun,
+		OutputFile: c.args.output,
+		Output:     os.Stdout,
+	})
+	if err != nil {
+		return fmt.Errorf("new writer: %w", err)
+	}
+
+	targetDir, err := filepath.Abs(c.args.targetDir)
+	if err != nil {
+		return fmt.Errorf("get target dir full path: %w", err)
+	}
+
+	fileList, err := prepareFileList(c.args)
+	if err != nil {
+		return fmt.Errorf("prepare decorators specifications: %w", err)
+	}
+
+	for _, item := range fileList {
+		fileName := fmt.Sprintf("%s%s.go", stringspkg.ToSnakeCase(item.Decorator.Name), c.args.fileSuffix)
+		filePath := filepath.Join(targetDir, fileName)
+
+		g := generator{
+			Package:   filepath.Base(targetDir),
+			Imports:   item.Imports,
+			Decorator: item.Decorator,
+			Template:  c.args.templateFile,
+		}
+
+		buf := bytes.NewBuffer(nil)
+		if err := g.Generate(buf); err != nil {
+			return fmt.Errorf("generate decorator(%s): %w", item.Decorator.Name, err)
+		}
+
+		if err := writer.WriteFile(filePath, buf.Bytes()); err != nil {
+			return fmt.Errorf("write decorator(%s): %w", item.Decorator.Name, err)
+		}
+	}
+
+	return writer.Err()
+}
+
+type decoratorFile struct {
+	Imports   astpkg.ImportList
+	Decorator decoratorSpec
+}
+
+func prepareFileList(args commandArgs) ([]decoratorFile, error) {
+	interfaceTypeList, err := interfacepkg.ParseInterfaceTypeList(args.interfaceType, "Logger")
+	if err != nil {
+		return nil, fmt.Errorf("parse interface types: %w", err)
+	}
+
+	redact, err := filterpkg.NewNameFilter("redact", args.redact)
+	if err != nil {
+		return nil, fmt.Errorf("new redact filter: %w", err)
+	}
+
+	skip, err := filterpkg.NewNameFilter("skip", args.skip)
+	if err != nil {
+		return nil, fmt.Errorf("new skip filter: %w", err)
+	}
+
+	decorators := make(map[string]decoratorSpec)
+
+	fileList, err := interfacepkg.PrepareFileList(interfacepkg.PrepareFileListParams{
+		InterfaceTypes: interfaceTypeList,
+		TargetDir:      args.targetDir,
+		Imports:        []string{"context", "log/slog", "time"},
+		UsedImports: func(spec *interfacepkg.Spec) []string {
+			decorator := newDecoratorSpec(newDecoratorSpecParams{spec: *spec, redact: redact, skip: skip})
+			decorators[spec.Name] = decorator
+
+			usedImports := []string{"log/slog"}
+			if decorator.HasLogged() {
+				usedImports = append(usedImports, "time")
+			}
+			if decorator.NeedsBackground() {
+				usedImports = append(usedImports, "context")
+			}
+
+			return usedImports
+		},
+		Reserved: reservedNames,
+		Cache:    args.packageCache,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if unmatched := redact.Unmatched(); len(unmatched) != 0 {
+		return nil, fmt.Errorf("redacted parameters are not found: %v", unmatched)
+	}
+	if unmatched := skip.Unmatched(); len(unmatched) != 0 {
+		return nil, fmt.Errorf("skipped methods are not found: %v", unmatched)
+	}
+
+	res := make([]decoratorFile, 0, len(fileList))
+	for _, item := range fileList {
+		res = append(res, decoratorFile{
+			Imports:   item.Imports,
+			Decorator: decorators[item.Spec.Name],
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,212 @@
+package log_decorator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute(t *testing.T) {
+	args := commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/decoratorpkg.IService",
+		targetDir:     "./",
+		fileSuffix:    "_generated",
+		redact:        []string{"password"},
+		skip:          []string{"Close"},
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "i_service_logger_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package log_decorator
+
+import (
+	context "context"
+	decoratorpkg "github.com/khevse/codegen/tests/decoratorpkg"
+	slog "log/slog"
+	time "time"
+)
+
+var _ decoratorpkg.IService = (*IServiceLogger)(nil)
+
+// IServiceLogger logs the calls of decoratorpkg.IService by slog: IService service of the users
+type IServiceLogger struct {
+	base   decoratorpkg.IService
+	logger *slog.Logger
+}
+
+// NewIServiceLogger returns the decorator logging the calls of the base object.
+func NewIServiceLogger(base decoratorpkg.IService, logger *slog.Logger) *IServiceLogger {
+	return &IServiceLogger{base: base, logger: logger}
+}
+
+// Close implements decoratorpkg.IService.
+func (d *IServiceLogger) Close() {
+	d.base.Close()
+}
+
+// Find implements decoratorpkg.IService.
+func (d *IServiceLogger) Find(ctx context.Context, ids ...int) (r0 []decoratorpkg.User, r1 error) {
+	dStart := time.Now()
+	r0, r1 = d.base.Find(ctx, ids...)
+
+	dLevel := slog.LevelInfo
+	dAttrs := []slog.Attr{
+		slog.Duration("duration", time.Since(dStart)),
+		slog.Group("params",
+			slog.Any("ids", ids),
+		),
+		slog.Group("results",
+			slog.Any("r0", r0),
+		),
+	}
+	if r1 != nil {
+		dLevel = slog.LevelError
+		dAttrs = append(dAttrs, slog.Any("error", r1))
+	}
+
+	d.logger.LogAttrs(ctx, dLevel, "IService.Find", dAttrs...)
+
+	return r0, r1
+}
+
+// Login implements decoratorpkg.IService.
+func (d *IServiceLogger) Login(ctx context.Context, user string, password string) (token string, err error) {
+	dStart := time.Now()
+	token, err = d.base.Login(ctx, user, password)
+
+	dLevel := slog.LevelInfo
+	dAttrs := []slog.Attr{
+		slog.Duration("duration", time.Since(dStart)),
+		slog.Group("params",
+			slog.Any("user", user),
+			slog.String("password", "[REDACTED]"),
+		),
+		slog.Group("results",
+			slog.Any("token", token),
+		),
+	}
+	if err != nil {
+		dLevel = slog.LevelError
+		dAttrs = append(dAttrs, slog.Any("error", err))
+	}
+
+	d.logger.LogAttrs(ctx, dLevel, "IService.Login", dAttrs...)
+
+	return token, err
+}
+
+// Name implements decoratorpkg.IService.
+func (d *IServiceLogger) Name() (r0 string) {
+	dStart := time.Now()
+	r0 = d.base.Name()
+
+	dLevel := slog.LevelInfo
+	dAttrs := []slog.Attr{
+		slog.Duration("duration", time.Since(dStart)),
+		slog.Group("results",
+			slog.Any("r0", r0),
+		),
+	}
+
+	d.logger.LogAttrs(context.Background(), dLevel, "IService.Name", dAttrs...)
+
+	return r0
+}
+
+// Ping implements decoratorpkg.IService.
+func (d *IServiceLogger) Ping() (r0 error) {
+	dStart := time.Now()
+	r0 = d.base.Ping()
+
+	dLevel := slog.LevelInfo
+	dAttrs := []slog.Attr{
+		slog.Duration("duration", time.Since(dStart)),
+	}
+	if r0 != nil {
+		dLevel = slog.LevelError
+		dAttrs = append(dAttrs, slog.Any("error", r0))
+	}
+
+	d.logger.LogAttrs(context.Background(), dLevel, "IService.Ping", dAttrs...)
+
+	return r0
+}
+`,
+		string(data),
+	)
+}
+
+func TestPrepareFileListFilters(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Redact  []string
+		Skip    []string
+		WantErr string
+	}{
+		"unknown redacted parameter": {
+			Redact:  []string{"password", "Find.secret"},
+			WantErr: "redacted parameters are not found: [Find.secret]",
+		},
+		"redacted parameter of other method": {
+			Redact:  []string{"Find.password"},
+			WantErr: "redacted parameters are not found: [Find.password]",
+		},
+		"invalid redacted parameter": {
+			Redact:  []string{"Find."},
+			WantErr: "new redact filter: invalid redact rule: Find.",
+		},
+		"unknown skipped method": {
+			Skip:    []string{"Close", "Open"},
+			WantErr: "skipped methods are not found: [Open]",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := prepareFileList(commandArgs{
+				interfaceType: "github.com/khevse/codegen/tests/decoratorpkg.IService",
+				targetDir:     "./",
+				redact:        tc.Redact,
+				skip:          tc.Skip,
+			})
+			require.EqualError(t, err, tc.WantErr)
+		})
+	}
+}
+
+func TestPrepareFileListRenamed(t *testing.T) {
+	t.Parallel()
+
+	list, err := prepareFileList(commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/decoratorpkg.IAudit",
+		targetDir:     "./",
+		redact:        []string{"Record.d", "span", "Record.span"},
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Len(t, list[0].Decorator.Methods, 1)
+
+	// the reserved name is renamed, the key of the attribute is the name from the interface,
+	// the parameter matches both redact rules
+	method := list[0].Decorator.Methods[0]
+	require.Equal(t, "ctx context.Context, p1 time.Duration, span string", method.ParamsDecl())
+	require.Equal(t,
+		[]logAttr{
+			{Key: "d", Name: "p1", Redacted: true},
+			{Key: "span", Name: "span", Redacted: true},
+		},
+		method.LogParams,
+	)
+}
@@ -0,0 +1,56 @@
+package log_decorator
+
+import (
+	"embed"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/khevse/codegen/internal/pkg/application"
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/templatepkg"
+)
+
+const templateFile = "file.tmpl"
+
+//go:embed file.tmpl
+var content embed.FS
+
+// defaultTemplate returns the embedded template.
+func defaultTemplate() ([]byte, error) {
+	return content.ReadFile(templateFile)
+}
+
+type generator struct {
+	Package   string
+	Imports   astpkg.ImportList
+	Decorator decoratorSpec
+	// Template is the path of the custom template file, optional
+	Template string
+}
+
+func (g generator) Generate(w io.Writer) error {
+	slices.SortFunc(g.Imports, func(i, j astpkg.Import) int {
+		return strings.Compare(i.Path, j.Path)
+	})
+	slices.SortFunc(g.Decorator.Methods, func(i, j methodSpec) int {
+		return strings.Compare(i.Name, j.Name)
+	})
+
+	params := templatepkg.ExecuteTemplateParams{
+		Writer:             w,
+		FS:                 content,
+		TemplateFile:       templateFile,
+		CustomTemplateFile: g.Template,
+		Data: map[string]any{
+			"package":       g.Package,
+			"imports":       g.Imports,
+			"decorator":     g.Decorator,
+			"redactedValue": redactedValue,
+			"appInfo":       application.GetInfo(),
+		},
+		Format: true,
+	}
+
+	return templatepkg.ExecuteTemplate(params)
+}
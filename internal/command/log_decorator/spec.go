@@ -0,0 +1,100 @@
+package log_decorator
+
+import (
+	"github.com/khevse/codegen/internal/pkg/filterpkg"
+	"github.com/khevse/codegen/internal/pkg/interfacepkg"
+	"github.com/samber/lo"
+)
+
+// redactedValue is logged instead of the values of the redacted parameters.
+const redactedValue = "[REDACTED]"
+
+// reservedNames are the names used by the generated methods, the arguments with these names are renamed.
+var reservedNames = []string{"d", "dStart", "dLevel", "dAttrs", "context", "slog", "time"}
+
+type logAttr struct {
+	// Key is the key of the attribute: the name of the argument in the interface,
+	// the generated name for the unnamed arguments.
+	Key string
+	// Name is the name of the argument in the generated method, the reserved names are renamed.
+	Name string
+	// Redacted is true if the value is replaced by [REDACTED].
+	Redacted bool
+}
+
+type methodSpec struct {
+	interfacepkg.Method
+	// Skip is true if the call is passed to the base object without logging.
+	Skip bool
+	// LogParams are the logged parameters, the context parameters are not logged.
+	LogParams []logAttr
+	// LogResults are the logged results, the error result is logged by the error attribute.
+	LogResults []logAttr
+}
+
+type decoratorSpec struct {
+	Name          string
+	InterfaceName string
+	TypeName      string
+	TypeParams    string
+	TypeArgs      string
+	Comment       string
+	Methods       []methodSpec
+}
+
+// HasLogged returns true if at least one method is logged.
+func (s decoratorSpec) HasLogged() bool {
+	return lo.SomeBy(s.Methods, func(item methodSpec) bool {
+		return !item.Skip
+	})
+}
+
+// NeedsBackground returns true if at least one logged method has no context parameter.
+func (s decoratorSpec) NeedsBackground() bool {
+	return lo.SomeBy(s.Methods, func(item methodSpec) bool {
+		return !item.Skip && item.ContextParam() == ""
+	})
+}
+
+// attrKey returns the key of the attribute of the argument.
+func attrKey(item interfacepkg.Field) string {
+	return lo.Ternary(item.SourceName != "", item.SourceName, item.Name)
+}
+
+type newDecoratorSpecParams struct {
+	spec   interfacepkg.Spec
+	redact *filterpkg.NameFilter
+	skip   *filterpkg.NameFilter
+}
+
+func newDecoratorSpec(params newDecoratorSpecParams) decoratorSpec {
+	methods := lo.Map(params.spec.Methods, func(method interfacepkg.Method, _ int) methodSpec {
+		errorResult := method.ErrorResult()
+
+		return methodSpec{
+			Method: method,
+			Skip:   params.skip.Match("", method.Name),
+			LogParams: lo.FilterMap(method.Params, func(item interfacepkg.Field, _ int) (logAttr, bool) {
+				key := attrKey(item)
+				return logAttr{
+					Key:      key,
+					Name:     item.Name,
+					Redacted: params.redact.Match(method.Name, key),
+				}, !item.IsContext()
+			}),
+			LogResults: lo.FilterMap(method.Results, func(item interfacepkg.Field, _ int) (logAttr, bool) {
+				return logAttr{Key: attrKey(item), Name: item.Name}, item.Name != errorResult
+			}),
+		}
+	})
+
+	return decoratorSpec{
+		Name:          params.spec.Name,
+		InterfaceName: params.spec.InterfaceName,
+		TypeName:      params.spec.TypeName,
+		TypeParams:    params.spec.TypeParams,
+		TypeArgs:      params.spec.TypeArgs,
+		Comment:       params.spec.Comment,
+		Methods:       methods,
+	}
+}
@@ -0,0 +1,198 @@
+package mock_creator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/command"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+	"github.com/khevse/codegen/internal/pkg/interfacepkg"
+	"github.com/khevse/codegen/internal/pkg/stringspkg"
+	"github.com/samber/lo"
+)
+
+type commandArgs struct {
+	interfaceType string
+	targetDir     string
+	fileSuffix    string
+	check         bool
+	dryRun        bool
+	output        string
+	templateFile  string
+
+	packageCache *astpkg.PackageCache
+}
+
+type Command struct {
+	args commandArgs
+}
+
+func New() *Command {
+	return new(Command)
+}
+
+func (c *Command) Name() string {
+	return "mock"
+}
+
+func (c *Command) ShortName() string {
+	return "m"
+}
+
+// SetPackageCache sets the cache of the parsed packages shared between the commands.
+func (c *Command) SetPackageCache(cache *astpkg.PackageCache) {
+	c.args.packageCache = cache
+}
+
+// DefaultTemplate returns the default template of the generated file.
+func (c *Command) DefaultTemplate() ([]byte, error) {
+	return defaultTemplate()
+}
+
+func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
+	const (
+		flagInterfaceType = "interface-type"
+		flagTargetDir     = "target-dir"
+		flagFileSuffix    = "suffix"
+		flagCheck         = "check"
+		flagDryRun        = "dry-run"
+		flagOutput        = "output"
+		flagTemplateFile  = "template"
+	)
+
+	flagSetter.Flags().StringVarP(
+		&c.args.interfaceType,
+		flagInterfaceType,
+		"i",
+		"",
+		"interface type for mock generation. Examples: <package>.<InterfaceName>; <package>.<InterfaceName>=<MockName>; <package>.<InterfaceName1>,<package>.<InterfaceName2>=<MockName2>",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.targetDir,
+		flagTargetDir,
+		"p",
+		"",
+		"target dir for the mocks",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.fileSuffix,
+		flagFileSuffix,
+		"",
+		"",
+		"result file suffix",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.check,
+		flagCheck,
+		"",
+		false,
+		"check that the generated files are up to date without writing them, the differences are printed",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.dryRun,
+		flagDryRun,
+		"",
+		false,
+		"list the files which would be written or changed without writing them",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.output,
+		flagOutput,
+		"",
+		"",
+		"print the generated code to stdout instead of writing the files, the only supported value is -",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.templateFile,
+		flagTemplateFile,
+		"",
+		"",
+		"custom template file used instead of the default template",
+	)
+
+	for _, flagName := range []string{flagInterfaceType, flagTargetDir} {
+		if err := flagSetter.MarkFlagRequired(flagName); err != nil {
+			return fmt.Errorf("mark flag as required(%s): %w", flagName, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Command) Execute() error {
+	targetDir, err := filepath.Abs(c.args.targetDir)
+	if err != nil {
+		return fmt.Errorf("get target dir full path: %w", err)
+	}
+
+	mockFileList, err := prepareMockFileList(c.args)
+	if err != nil {
+		return fmt.Errorf("prepare mocks specifications: %w", err)
+	}
+
+	writer, err := filepkg.NewWriter(filepkg.WriterParams{
+		Check:      c.args.check,
+		DryRun:     c.args.dryRun,
+		OutputFile: c.args.output,
+		Output:     os.Stdout,
+	})
+	if err != nil {
+		return fmt.Errorf("new writer: %w", err)
+	}
+
+	for _, item := range mockFileList {
+		fileName := fmt.Sprintf("%s%s.go", stringspkg.ToSnakeCase(item.MockSpec.Name), c.args.fileSuffix)
+		filePath := filepath.Join(targetDir, fileName)
+
+		g := generator{
+			Package:  filepath.Base(targetDir),
+			Imports:  item.Imports,
+			MockSpec: item.MockSpec,
+			Template: c.args.templateFile,
+		}
+
+		buf := bytes.NewBuffer(nil)
+		if err := g.Generate(buf); err != nil {
+			return fmt.Errorf("generate mock(%s): %w", item.MockSpec.Name, err)
+		}
+
+		if err := writer.WriteFile(filePath, buf.Bytes()); err != nil {
+			return fmt.Errorf("write mock(%s): %w", item.MockSpec.Name, err)
+		}
+	}
+
+	return writer.Err()
+}
+
+type mockFile struct {
+	Imports  astpkg.ImportList
+	MockSpec mockSpec
+}
+
+func prepareMockFileList(args commandArgs) ([]mockFile, error) {
+	interfaceTypeList, err := interfacepkg.ParseInterfaceTypeList(args.interfaceType, "Mock")
+	if err != nil {
+		return nil, fmt.Errorf("parse interface types: %w", err)
+	}
+
+	fileList, err := interfacepkg.PrepareFileList(interfacepkg.PrepareFileListParams{
+		InterfaceTypes: interfaceTypeList,
+		TargetDir:      args.targetDir,
+		Reserved:       reservedNames,
+		NameKey:        fieldName,
+		Cache:          args.packageCache,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(fileList, func(item interfacepkg.File, _ int) mockFile {
+		return mockFile{
+			Imports:  item.Imports,
+			MockSpec: newMockSpec(item.Spec),
+		}
+	}), nil
+}
@@ -0,0 +1,369 @@
+package mock_creator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareMockFileList(t *testing.T) {
+	t.Parallel()
+
+	args := commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/mainpkg.IFactory=FactoryMock",
+		targetDir:     "./../../../tests/mainpkg/mocks",
+		fileSuffix:    "",
+	}
+	list, err := prepareMockFileList(args)
+	require.NoError(t, err)
+
+	stringerType := func(name string) *astpkg.Ident {
+		return &astpkg.Ident{
+			Package:     "mainpkg",
+			PackagePath: "github.com/khevse/codegen/tests/mainpkg",
+			Name:        name,
+			Type: &astpkg.InterfaceType{
+				Methods: []*astpkg.Field{
+					{
+						Name: "String",
+						Type: &astpkg.FuncType{
+							Params:  []*astpkg.Field{},
+							Results: []*astpkg.Field{{Name: "", Type: &astpkg.Ident{Name: "string"}}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	require.Empty(t, cmp.Diff(
+		[]mockFile{
+			{
+				Imports: astpkg.ImportList{
+					{Alias: "mainpkg", Path: "github.com/khevse/codegen/tests/mainpkg"},
+				},
+				MockSpec: mockSpec{
+					Name:          "FactoryMock",
+					InterfaceName: "mainpkg.IFactory",
+					TypeParams:    "",
+					TypeArgs:      "",
+					Comment:       "FactoryMock mock for type IFactory: IFactory .",
+					Methods: []methodSpec{
+						{
+							Name: "NewObject1",
+							Params: []field{
+								{
+									Name:          "p0",
+									FieldName:     "P0",
+									TypeName:      "string",
+									FieldTypeName: "string",
+									Type:          &astpkg.Ident{Name: "string"},
+								},
+							},
+							Results: []field{
+								{
+									Name:          "r0",
+									FieldName:     "R0",
+									TypeName:      "mainpkg.IObject1",
+									FieldTypeName: "mainpkg.IObject1",
+									Type:          stringerType("IObject1"),
+								},
+							},
+						},
+						{
+							Name: "NewObject2",
+							Params: []field{
+								{
+									Name:          "val",
+									FieldName:     "Val",
+									TypeName:      "string",
+									FieldTypeName: "string",
+									Type:          &astpkg.Ident{Name: "string"},
+								},
+							},
+							Results: []field{
+								{
+									Name:          "r0",
+									FieldName:     "R0",
+									TypeName:      "mainpkg.IObject2",
+									FieldTypeName: "mainpkg.IObject2",
+									Type:          stringerType("IObject2"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		list,
+	))
+}
+
+func TestPrepareMockFileListNames(t *testing.T) {
+	t.Parallel()
+
+	args := commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/mockpkg.INames,github.com/khevse/codegen/tests/mockpkg.IStore",
+		targetDir:     "./",
+	}
+	list, err := prepareMockFileList(args)
+	require.NoError(t, err)
+
+	type methodView struct {
+		Params  []string
+		Results []string
+	}
+	fieldNames := func(fieldList []field) []string {
+		return lo.Map(fieldList, func(item field, _ int) string { return item.Name + ":" + item.FieldName })
+	}
+
+	got := make(map[string]methodView)
+	for _, file := range list {
+		for _, method := range file.MockSpec.Methods {
+			got[file.MockSpec.Name+"."+method.Name] = methodView{
+				Params:  fieldNames(method.Params),
+				Results: fieldNames(method.Results),
+			}
+		}
+	}
+
+	want := map[string]methodView{
+		// the names of the fields are the same
+		"INamesMock.Same": {
+			Params:  []string{"a:A", "p1:P1"},
+			Results: []string{"r0:R0"},
+		},
+		// the names are used by the generated code
+		"INamesMock.Reserved": {
+			Params:  []string{"p0:P0", "p1:P1", "p2:P2", "p3:P3", "p4:P4", "p5:P5"},
+			Results: []string{"r0:R0"},
+		},
+		// the generated name is used by the argument
+		"INamesMock.Indexed": {
+			Params:  []string{"p1:P1", "p1_1:P1_1"},
+			Results: []string{"r0:R0", "r1:R1"},
+		},
+		"IStoreMock.Get": {
+			Params:  []string{"k:K"},
+			Results: []string{"v:V", "ok:Ok"},
+		},
+	}
+	require.Empty(t, cmp.Diff(want, got))
+}
+
+func TestExecute(t *testing.T) {
+	args := commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/mainpkg.IObject1",
+		targetDir:     "./",
+		fileSuffix:    "_generated",
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "i_object1_mock_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package mock_creator
+
+import (
+	"fmt"
+	mainpkg "github.com/khevse/codegen/tests/mainpkg"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+)
+
+var _ mainpkg.IObject1 = (*IObject1Mock)(nil)
+
+/* IObject1Mock mock for type IObject1: IObject1 comment . */
+type IObject1Mock struct {
+	t          testing.TB
+	finishOnce sync.Once
+
+	StringMock *IObject1MockString
+}
+
+// NewIObject1Mock returns the mock object, the expectations are checked by t.Cleanup.
+func NewIObject1Mock(t testing.TB) *IObject1Mock {
+	m := &IObject1Mock{t: t}
+	m.StringMock = &IObject1MockString{mock: m}
+
+	t.Cleanup(m.Finish)
+
+	return m
+}
+
+// Finish checks that all expectations are met.
+func (m *IObject1Mock) Finish() {
+	m.finishOnce.Do(func() {
+		m.StringMock.finish()
+	})
+}
+
+// IObject1MockStringParams contains the arguments of the String call.
+type IObject1MockStringParams struct {
+}
+
+// IObject1MockStringResults contains the results of the String call.
+type IObject1MockStringResults struct {
+	R0 string
+}
+
+// IObject1MockString mock of the String method.
+type IObject1MockString struct {
+	mock         *IObject1Mock
+	mu           sync.Mutex
+	expectations []*IObject1MockStringExpectation
+	calls        []IObject1MockStringParams
+}
+
+// IObject1MockStringExpectation expectation of the String call.
+type IObject1MockStringExpectation struct {
+	parent  *IObject1MockString
+	desc    string
+	match   func(params IObject1MockStringParams) bool
+	results []IObject1MockStringResults
+	times   uint64
+	counter uint64
+}
+
+// Expect adds the expectation of the call with the arguments equal to the values.
+func (mm *IObject1MockString) Expect() *IObject1MockStringExpectation {
+	mmParams := IObject1MockStringParams{}
+
+	return mm.add(fmt.Sprintf("%+v", mmParams), func(params IObject1MockStringParams) bool {
+		return reflect.DeepEqual(params, mmParams)
+	})
+}
+
+// ExpectMatch adds the expectation of the call with the arguments accepted by the matcher.
+func (mm *IObject1MockString) ExpectMatch(match func(params IObject1MockStringParams) bool) *IObject1MockStringExpectation {
+	return mm.add("matcher", match)
+}
+
+// ExpectAny adds the expectation of the call with any arguments.
+func (mm *IObject1MockString) ExpectAny() *IObject1MockStringExpectation {
+	return mm.add("any arguments", func(IObject1MockStringParams) bool {
+		return true
+	})
+}
+
+// Calls returns the arguments of all calls.
+func (mm *IObject1MockString) Calls() []IObject1MockStringParams {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	return slices.Clone(mm.calls)
+}
+
+// CallsCount returns the number of calls.
+func (mm *IObject1MockString) CallsCount() uint64 {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	return uint64(len(mm.calls))
+}
+
+func (mm *IObject1MockString) add(desc string, match func(params IObject1MockStringParams) bool) *IObject1MockStringExpectation {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	expectation := &IObject1MockStringExpectation{parent: mm, desc: desc, match: match}
+	mm.expectations = append(mm.expectations, expectation)
+
+	return expectation
+}
+
+func (mm *IObject1MockString) call(params IObject1MockStringParams) IObject1MockStringResults {
+	mm.mock.t.Helper()
+
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	mm.calls = append(mm.calls, params)
+
+	for _, expectation := range mm.expectations {
+		if expectation.times > 0 && expectation.counter >= expectation.times {
+			continue
+		}
+		if !expectation.match(params) {
+			continue
+		}
+
+		expectation.counter++
+		if len(expectation.results) == 0 {
+			return IObject1MockStringResults{}
+		}
+
+		return expectation.results[min(expectation.counter, uint64(len(expectation.results)))-1]
+	}
+
+	mm.mock.t.Fatalf("unexpected call IObject1Mock.String(%+v)", params)
+
+	return IObject1MockStringResults{}
+}
+
+func (mm *IObject1MockString) finish() {
+	mm.mock.t.Helper()
+
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	for _, expectation := range mm.expectations {
+		switch {
+		case expectation.times == 0 && expectation.counter == 0:
+			mm.mock.t.Errorf("expected call IObject1Mock.String with %s", expectation.desc)
+		case expectation.times > 0 && expectation.counter != expectation.times:
+			mm.mock.t.Errorf(
+				"expected %d calls IObject1Mock.String with %s, got %d",
+				expectation.times, expectation.desc, expectation.counter,
+			)
+		}
+	}
+}
+
+// Return adds the results to the sequence: the calls return the results in the order,
+// the last results are returned for the rest calls.
+func (e *IObject1MockStringExpectation) Return(r0 string) *IObject1MockStringExpectation {
+	e.results = append(e.results, IObject1MockStringResults{R0: r0})
+	return e
+}
+
+// Times sets the expected number of calls, by default the expectation is expected to be called at least once.
+func (e *IObject1MockStringExpectation) Times(n uint64) *IObject1MockStringExpectation {
+	e.times = n
+	return e
+}
+
+// CallsCount returns the number of calls matched the expectation.
+func (e *IObject1MockStringExpectation) CallsCount() uint64 {
+	e.parent.mu.Lock()
+	defer e.parent.mu.Unlock()
+
+	return e.counter
+}
+
+// String implements mainpkg.IObject1.
+func (mm *IObject1Mock) String() string {
+	mm.t.Helper()
+
+	mmResults := mm.StringMock.call(IObject1MockStringParams{})
+
+	return mmResults.R0
+}
+`,
+		string(data),
+	)
+}
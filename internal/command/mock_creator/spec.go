@@ -0,0 +1,112 @@
+package mock_creator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/interfacepkg"
+	"github.com/khevse/codegen/internal/pkg/stringspkg"
+	"github.com/samber/lo"
+)
+
+type field struct {
+	// Name is the name of the argument in the method signature.
+	Name string
+	// FieldName is the name of the field in the params or results structure.
+	FieldName string
+	// TypeName is the type in the method signature.
+	TypeName string
+	// FieldTypeName is the type of the field in the params or results structure.
+	FieldTypeName string
+	Type          astpkg.Type
+}
+
+type methodSpec struct {
+	Name    string
+	Params  []field
+	Results []field
+}
+
+// ParamsDecl returns the parameters of the method signature: id ID, args ...string.
+func (s methodSpec) ParamsDecl() string {
+	return joinFields(s.Params, func(item field) string {
+		return item.Name + " " + item.TypeName
+	})
+}
+
+// ResultsDecl returns the results of the method signature: (T, error).
+func (s methodSpec) ResultsDecl() string {
+	if len(s.Results) == 0 {
+		return ""
+	}
+
+	return "(" + joinFields(s.Results, func(item field) string {
+		return item.TypeName
+	}) + ")"
+}
+
+// ReturnDecl returns the parameters of the Return method: r0 T, r1 error.
+func (s methodSpec) ReturnDecl() string {
+	return joinFields(s.Results, func(item field) string {
+		return item.Name + " " + item.TypeName
+	})
+}
+
+type mockSpec struct {
+	Name          string
+	InterfaceName string
+	TypeParams    string
+	TypeArgs      string
+	Comment       string
+	Methods       []methodSpec
+}
+
+// reservedNames are the names used by the generated methods, the arguments with the same names are renamed.
+var reservedNames = []string{"m", "mm", "e", "mmParams", "mmResults", "fmt", "reflect", "slices", "sync", "testing"}
+
+func newMockSpec(spec interfacepkg.Spec) mockSpec {
+	return mockSpec{
+		Name:          spec.Name,
+		InterfaceName: spec.InterfaceName,
+		TypeParams:    spec.TypeParams,
+		TypeArgs:      spec.TypeArgs,
+		Comment:       fmt.Sprintf("%s mock for type %s: %s", spec.Name, spec.TypeName, spec.Comment),
+		Methods: lo.Map(spec.Methods, func(item interfacepkg.Method, _ int) methodSpec {
+			return methodSpec{
+				Name:    item.Name,
+				Params:  newFieldsList(item.Params),
+				Results: newFieldsList(item.Results),
+			}
+		}),
+	}
+}
+
+// newFieldsList returns the arguments with the fields of the params or results structure,
+// the names of the arguments are unique by fieldName.
+func newFieldsList(src []interfacepkg.Field) []field {
+	return lo.Map(src, func(item interfacepkg.Field, _ int) field {
+		f := field{
+			Name:          item.Name,
+			FieldName:     fieldName(item.Name),
+			TypeName:      item.TypeName,
+			FieldTypeName: item.TypeName,
+			Type:          item.Type,
+		}
+
+		if ellipsis, ok := item.Type.(*astpkg.EllipsisType); ok {
+			f.FieldTypeName = "[]" + ellipsis.Type.ExprString()
+		}
+
+		return f
+	})
+}
+
+// fieldName returns the name of the field in the params or results structure.
+func fieldName(name string) string { return stringspkg.UpperFirst(name) }
+
+func joinFields(fieldList []field, fn func(item field) string) string {
+	return strings.Join(lo.Map(fieldList, func(item field, _ int) string {
+		return fn(item)
+	}), ", ")
+}
@@ -1,13 +1,19 @@
 package object_test_wrapper
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/khevse/codegen/internal/pkg/astpkg"
 	"github.com/khevse/codegen/internal/pkg/command"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+	"github.com/khevse/codegen/internal/pkg/filterpkg"
+	"github.com/khevse/codegen/internal/pkg/stringspkg"
 	"github.com/samber/lo"
 )
 
@@ -15,7 +21,18 @@ type commandArgs struct {
 	interfaceType string
 	targetDir     string
 	mockPackage   string
+	mockBackend   string
 	fileSuffix    string
+	check         bool
+	dryRun        bool
+	output        string
+	templateFile  string
+	annotated     string
+	filter        filterpkg.Params
+
+	// methodFilter is shared by the generations of the annotated interfaces to check the rules once.
+	methodFilter *filterpkg.MethodFilter
+	packageCache *astpkg.PackageCache
 }
 
 type Command struct {
@@ -34,12 +51,32 @@ func (c *Command) ShortName() string {
 	return "w"
 }
 
+// SetPackageCache sets the cache of the parsed packages shared between the commands.
+func (c *Command) SetPackageCache(cache *astpkg.PackageCache) {
+	c.args.packageCache = cache
+}
+
+// DefaultTemplate returns the default template of the generated file.
+func (c *Command) DefaultTemplate() ([]byte, error) {
+	return defaultTemplate()
+}
+
 func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
 	const (
 		flagInterfaceType = "interface-type"
 		flagTargetDir     = "target-dir"
 		flagMockPackage   = "mock-package"
+		flagMockBackend   = "mock-backend"
 		flagFileSuffix    = "suffix"
+		flagCheck         = "check"
+		flagDryRun        = "dry-run"
+		flagOutput        = "output"
+		flagTemplateFile  = "template"
+		flagAnnotated     = "annotated"
+		flagInclude       = "include"
+		flagExclude       = "exclude"
+		flagIncludeRegex  = "include-regex"
+		flagExcludeRegex  = "exclude-regex"
 	)
 
 	flagSetter.Flags().StringVarP(
@@ -63,6 +100,16 @@ func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
 		"",
 		"mocks package",
 	)
+	flagSetter.Flags().StringVarP(
+		&c.args.mockBackend,
+		flagMockBackend,
+		"",
+		mockBackendMinimock,
+		fmt.Sprintf(
+			"mocks generator: %s, %s, %s, %s",
+			mockBackendMinimock, mockBackendGomock, mockBackendMockery, mockBackendCodegen,
+		),
+	)
 	flagSetter.Flags().StringVarP(
 		&c.args.fileSuffix,
 		flagFileSuffix,
@@ -70,52 +117,177 @@ func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
 		"",
 		"result file suffix",
 	)
-
-	for _, flagName := range []string{flagInterfaceType, flagTargetDir, flagMockPackage} {
-		if err := flagSetter.MarkFlagRequired(flagName); err != nil {
-			return fmt.Errorf("mark flag as required(%s): %w", flagName, err)
-		}
-	}
+	flagSetter.Flags().BoolVarP(
+		&c.args.check,
+		flagCheck,
+		"",
+		false,
+		"check that the generated files are up to date without writing them, the differences are printed",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.dryRun,
+		flagDryRun,
+		"",
+		false,
+		"list the files which would be written or changed without writing them",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.output,
+		flagOutput,
+		"",
+		"",
+		"print the generated code to stdout instead of writing the files, the only supported value is -",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.templateFile,
+		flagTemplateFile,
+		"",
+		"",
+		"custom template file used instead of the default template",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.annotated,
+		flagAnnotated,
+		"",
+		"",
+		"packages pattern (./...) of the interfaces annotated by //codegen:wrapper [name=<WrapperName>] [mock=<mocks package or dir>] [dir=<target dir>] [backend=<mocks generator>], replaces --interface-type and --target-dir",
+	)
+	flagSetter.Flags().StringSliceVarP(
+		&c.args.filter.Include,
+		flagInclude,
+		"",
+		nil,
+		"methods wrapped by the mocks: <MethodName> or <InterfaceName>.<MethodName>, the other methods of the interface call the base object",
+	)
+	flagSetter.Flags().StringSliceVarP(
+		&c.args.filter.Exclude,
+		flagExclude,
+		"",
+		nil,
+		"methods calling the base object: <MethodName> or <InterfaceName>.<MethodName>, the methods with //codegen:ignore are always excluded",
+	)
+	flagSetter.Flags().StringSliceVarP(
+		&c.args.filter.IncludeRegex,
+		flagIncludeRegex,
+		"",
+		nil,
+		"regular expressions of the methods wrapped by the mocks, matched against <InterfaceName>.<MethodName>",
+	)
+	flagSetter.Flags().StringSliceVarP(
+		&c.args.filter.ExcludeRegex,
+		flagExcludeRegex,
+		"",
+		nil,
+		"regular expressions of the methods calling the base object, matched against <InterfaceName>.<MethodName>",
+	)
 
 	return nil
 }
 
 func (c *Command) Execute() error {
-	targetDir, err := filepath.Abs(c.args.targetDir)
+	writer, err := filepkg.NewWriter(filepkg.WriterParams{
+		Check:      c.args.check,
+		DryRun:     c.args.dryRun,
+		OutputFile: c.args.output,
+		Output:     os.Stdout,
+	})
 	if err != nil {
-		return fmt.Errorf("get target dir full path: %w", err)
+		return fmt.Errorf("new writer: %w", err)
 	}
 
-	importList, objectSpec, err := prepareObjectSpec(c.args)
+	c.args.methodFilter, err = filterpkg.NewMethodFilter(c.args.filter)
 	if err != nil {
-		return fmt.Errorf("prepare object specification: %w", err)
+		return fmt.Errorf("new method filter: %w", err)
 	}
 
-	fileName := fmt.Sprintf("wrapper%s.go", c.args.fileSuffix)
-	filePath := filepath.Join(targetDir, fileName)
+	if c.args.annotated == "" && (c.args.interfaceType == "" || c.args.targetDir == "" || c.args.mockPackage == "") {
+		return errors.New("flags --interface-type, --target-dir and --mock-package are required without --annotated")
+	}
 
-	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_APPEND|os.O_RDWR, os.ModePerm)
-	if err != nil {
-		return fmt.Errorf("create file(%s): %w", filePath, err)
+	var specList []*Spec
+	if c.args.annotated != "" {
+		argsList, err := getAnnotatedArgsList(c.args)
+		if err != nil {
+			return fmt.Errorf("get annotated types: %w", err)
+		}
+
+		for _, args := range argsList {
+			interfaceType, err := parseInterfaceType(args.interfaceType)
+			if err != nil {
+				return fmt.Errorf("parse interface type: %w", err)
+			}
+
+			// the wrappers of the same target dir are generated to the different files
+			fileName := fmt.Sprintf("%s%s.go", stringspkg.ToSnakeCase(interfaceType.WrapperName), args.fileSuffix)
+			spec, err := newSpec(args, fileName)
+			if err != nil {
+				return fmt.Errorf("generate(%s): %w", interfaceType.WrapperName, err)
+			}
+
+			specList = append(specList, spec)
+		}
+	} else {
+		spec, err := newSpec(c.args, fmt.Sprintf("wrapper%s.go", c.args.fileSuffix))
+		if err != nil {
+			return err
+		}
+
+		specList = append(specList, spec)
 	}
-	defer func() {
-		f.Close()
-	}()
-	if err := f.Truncate(0); err != nil {
-		return fmt.Errorf("truncate file: %w", err)
+
+	// the files are not written if the rules of the filter have not matched any method
+	if err := c.args.methodFilter.Err(); err != nil {
+		return err
 	}
 
-	g := generator{
-		Package:    filepath.Base(targetDir),
-		Imports:    importList,
-		ObjectSpec: *objectSpec,
+	for _, spec := range specList {
+		buf := bytes.NewBuffer(nil)
+		if err := spec.Generate(buf); err != nil {
+			return fmt.Errorf("generate: %w", err)
+		}
+
+		if err := writer.WriteFile(spec.FilePath, buf.Bytes()); err != nil {
+			return err
+		}
 	}
 
-	if err := g.Generate(f); err != nil {
-		return fmt.Errorf("generate: %w", err)
+	return writer.Err()
+}
+
+// getAnnotatedArgsList returns the arguments of the interfaces annotated by the directive:
+// //codegen:wrapper [name=<WrapperName>] [mock=<mocks package or dir>] [dir=<target dir>] [backend=<mocks generator>].
+// The relative dirs are resolved from the dir of the interface package.
+func getAnnotatedArgsList(args commandArgs) ([]commandArgs, error) {
+	const directiveName = "wrapper"
+
+	annotatedTypes, err := astpkg.FindAnnotatedTypes(args.packageCache, directiveName, args.annotated)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	res := make([]commandArgs, 0, len(annotatedTypes))
+	for _, item := range annotatedTypes {
+		mockPackage := item.Directive.Arg("mock", args.mockPackage)
+		if mockPackage == "" {
+			return nil, fmt.Errorf("mock package is not set(%s.%s)", item.Package.Path, item.TypeDecl.Name)
+		}
+		if strings.HasPrefix(mockPackage, ".") {
+			mockPackage = path.Join(item.Package.Path, mockPackage)
+		}
+
+		itemArgs := args
+		itemArgs.annotated = ""
+		itemArgs.interfaceType = fmt.Sprintf(
+			"%s.%s=%s",
+			item.Package.Path, item.TypeDecl.Name, item.Directive.Arg("name", item.TypeDecl.Name+"Wrapper"),
+		)
+		itemArgs.targetDir = filepath.Join(item.Package.Dir, item.Directive.Arg("dir", "."))
+		itemArgs.mockPackage = mockPackage
+		itemArgs.mockBackend = item.Directive.Arg("backend", args.mockBackend)
+		res = append(res, itemArgs)
+	}
+
+	return res, nil
 }
 
 func prepareObjectSpec(args commandArgs) (astpkg.ImportList, *objectSpec, error) {
@@ -124,7 +296,12 @@ func prepareObjectSpec(args commandArgs) (astpkg.ImportList, *objectSpec, error)
 		return nil, nil, fmt.Errorf("parse interface type: %w", err)
 	}
 
-	pkg, err := astpkg.ParsePackage(interfaceType.Package)
+	backend, err := getMockBackend(args.mockBackend)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get mock backend: %w", err)
+	}
+
+	pkg, err := args.packageCache.ParsePackage(interfaceType.Package)
 	if err != nil {
 		return nil, nil, fmt.Errorf("parse package(%s): %w", interfaceType.Package, err)
 	}
@@ -160,7 +337,7 @@ func prepareObjectSpec(args commandArgs) (astpkg.ImportList, *objectSpec, error)
 		return nil, nil, fmt.Errorf("not found type: %s", interfaceType.TypeName)
 	}
 
-	factoryDesc, err := newObjectSpec(interfaceType, mockPackage, typeDecl, imports)
+	factoryDesc, err := newObjectSpec(interfaceType, mockPackage, backend, typeDecl, imports, args.methodFilter)
 	if err != nil {
 		return nil, nil, fmt.Errorf(
 			"new factory description(%s): %w",
@@ -170,11 +347,17 @@ func prepareObjectSpec(args commandArgs) (astpkg.ImportList, *objectSpec, error)
 
 	clearImports := func() astpkg.ImportList {
 		usedImports := make(map[string]struct{})
+		if objectPackage, ok := imports.GetByPath(interfaceType.Package); ok {
+			usedImports[objectPackage.Alias] = struct{}{}
+		}
 		addUsedImport := func(t astpkg.Type) {
 			for _, item := range t.Imports() {
 				usedImports[item.Alias] = struct{}{}
 			}
 		}
+		for _, item := range typeDecl.TypeParams {
+			addUsedImport(item.Type)
+		}
 		for _, item := range factoryDesc.Fields {
 			addUsedImport(item.Type)
 			usedImports[filepath.Base(item.MockPackage)] = struct{}{}
@@ -182,11 +365,15 @@ func prepareObjectSpec(args commandArgs) (astpkg.ImportList, *objectSpec, error)
 		for _, method := range factoryDesc.Methods {
 			for _, item := range method.Params {
 				addUsedImport(item.Type)
-				usedImports[filepath.Base(item.MockPackage)] = struct{}{}
+				if !method.Excluded {
+					usedImports[filepath.Base(item.MockPackage)] = struct{}{}
+				}
 			}
 			for _, item := range method.Results {
 				addUsedImport(item.Type)
-				usedImports[filepath.Base(item.MockPackage)] = struct{}{}
+				if !method.Excluded {
+					usedImports[filepath.Base(item.MockPackage)] = struct{}{}
+				}
 			}
 		}
 
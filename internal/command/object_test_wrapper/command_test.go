@@ -3,11 +3,14 @@ package object_test_wrapper
 import (
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+	"github.com/khevse/codegen/internal/pkg/filterpkg"
 	"github.com/stretchr/testify/require"
 )
 
@@ -538,3 +541,483 @@ func (b *FactoryWrapperBuilder) SetIObject2Mock(val *FactoryWrapperMocks) *Facto
 		string(data),
 	)
 }
+
+func TestExecuteGenericInterface(t *testing.T) {
+	args := commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/mainpkg.IRepository=RepositoryWrapper",
+		targetDir:     "./",
+		fileSuffix:    "_generic_generated",
+		mockPackage:   "github.com/khevse/codegen/tests/mainpkg/mocks",
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "wrapper_generic_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package object_test_wrapper
+
+import (
+	minimock "github.com/gojuno/minimock/v3"
+	mainpkg "github.com/khevse/codegen/tests/mainpkg"
+	mocks "github.com/khevse/codegen/tests/mainpkg/mocks"
+	"testing"
+)
+
+// RepositoryWrapper mocks
+type RepositoryWrapperMocks struct {
+	IObject1 *mocks.IObject1Mock
+}
+
+// NewRepositoryWrapperMocks return object RepositoryWrapperMocks
+func NewRepositoryWrapperMocks(t *testing.T) *RepositoryWrapperMocks {
+	mc := minimock.NewController(t)
+
+	return &RepositoryWrapperMocks{
+		IObject1: mocks.NewIObject1Mock(mc),
+	}
+}
+
+/* RepositoryWrapper wrapper for type IRepository: IRepository comment */
+type RepositoryWrapper[T any, ID mainpkg.Number] struct {
+	mocks   RepositoryWrapperMocks
+	base    mainpkg.IRepository[T, ID]
+	GetArg0 T
+	GetArg1 error
+}
+
+/* Get . */
+func (w *RepositoryWrapper[T, ID]) Get(id ID) (_ T, _ error) {
+	existsMock := false
+	if existsMock {
+		return w.GetArg0, w.GetArg1
+	}
+
+	return w.base.Get(id)
+}
+
+/* Object . */
+func (w *RepositoryWrapper[T, ID]) Object(id ID) (_ mainpkg.IObject1) {
+	existsMock := false ||
+		w.mocks.IObject1 != nil
+	if existsMock {
+		return w.mocks.IObject1
+	}
+
+	return w.base.Object(id)
+}
+
+// RepositoryWrapperBuilder wrapper builder
+type RepositoryWrapperBuilder[T any, ID mainpkg.Number] struct {
+	object RepositoryWrapper[T, ID]
+}
+
+// SetBase set the base object with default behavior
+func (b *RepositoryWrapperBuilder[T, ID]) SetBase(val mainpkg.IRepository[T, ID]) *RepositoryWrapperBuilder[T, ID] {
+	b.object.base = val
+	return b
+}
+
+// Build return wrapper object
+func (b *RepositoryWrapperBuilder[T, ID]) Build() *RepositoryWrapper[T, ID] {
+	return &b.object
+}
+
+// SetAllMocks set all mocks objects
+func (b *RepositoryWrapperBuilder[T, ID]) SetAllMocks(val *RepositoryWrapperMocks) *RepositoryWrapperBuilder[T, ID] {
+	b.SetIObject1Mock(val)
+
+	return b
+}
+
+// SetIObject1Mock set mock object
+func (b *RepositoryWrapperBuilder[T, ID]) SetIObject1Mock(val *RepositoryWrapperMocks) *RepositoryWrapperBuilder[T, ID] {
+	b.object.mocks.IObject1 = val.IObject1
+	return b
+}
+`,
+		string(data),
+	)
+}
+
+func TestExecuteEmbeddedInterface(t *testing.T) {
+	args := commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/mainpkg.IEmbedded=EmbeddedWrapper",
+		targetDir:     "./",
+		fileSuffix:    "_embedded_generated",
+		mockPackage:   "github.com/khevse/codegen/tests/mainpkg/mocks",
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "wrapper_embedded_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package object_test_wrapper
+
+import (
+	mainpkg "github.com/khevse/codegen/tests/mainpkg"
+	"testing"
+)
+
+// EmbeddedWrapper mocks
+type EmbeddedWrapperMocks struct {
+}
+
+// NewEmbeddedWrapperMocks return object EmbeddedWrapperMocks
+func NewEmbeddedWrapperMocks(t *testing.T) *EmbeddedWrapperMocks {
+	return &EmbeddedWrapperMocks{}
+}
+
+/* EmbeddedWrapper wrapper for type IEmbedded: IEmbedded comment . */
+type EmbeddedWrapper struct {
+	mocks           EmbeddedWrapperMocks
+	base            mainpkg.IEmbedded
+	CloseArg0       error
+	NameArg0        string
+	OtherMethodArg0 any
+	StringArg0      string
+}
+
+/* Close . */
+func (w *EmbeddedWrapper) Close() (_ error) {
+	existsMock := false
+	if existsMock {
+		return w.CloseArg0
+	}
+
+	return w.base.Close()
+}
+
+/* Name . */
+func (w *EmbeddedWrapper) Name() (_ string) {
+	existsMock := false
+	if existsMock {
+		return w.NameArg0
+	}
+
+	return w.base.Name()
+}
+
+/* OtherMethod . */
+func (w *EmbeddedWrapper) OtherMethod() (_ any) {
+	existsMock := false
+	if existsMock {
+		return w.OtherMethodArg0
+	}
+
+	return w.base.OtherMethod()
+}
+
+/* String . */
+func (w *EmbeddedWrapper) String() (_ string) {
+	existsMock := false
+	if existsMock {
+		return w.StringArg0
+	}
+
+	return w.base.String()
+}
+
+// EmbeddedWrapperBuilder wrapper builder
+type EmbeddedWrapperBuilder struct {
+	object EmbeddedWrapper
+}
+
+// SetBase set the base object with default behavior
+func (b *EmbeddedWrapperBuilder) SetBase(val mainpkg.IEmbedded) *EmbeddedWrapperBuilder {
+	b.object.base = val
+	return b
+}
+
+// Build return wrapper object
+func (b *EmbeddedWrapperBuilder) Build() *EmbeddedWrapper {
+	return &b.object
+}
+
+// SetAllMocks set all mocks objects
+func (b *EmbeddedWrapperBuilder) SetAllMocks(val *EmbeddedWrapperMocks) *EmbeddedWrapperBuilder {
+
+	return b
+}
+`,
+		string(data),
+	)
+}
+
+func TestExecuteMockBackend(t *testing.T) {
+	for _, tc := range []struct {
+		Backend     string
+		WantImports string
+		WantMocks   string
+	}{
+		{
+			Backend: mockBackendGomock,
+			WantImports: `import (
+	mainpkg "github.com/khevse/codegen/tests/mainpkg"
+	mocks "github.com/khevse/codegen/tests/mainpkg/mocks"
+	gomock "go.uber.org/mock/gomock"
+	"testing"
+)`,
+			WantMocks: `// FactoryWrapper mocks
+type FactoryWrapperMocks struct {
+	IObject1 *mocks.MockIObject1
+	IObject2 *mocks.MockIObject2
+}
+
+// NewFactoryWrapperMocks return object FactoryWrapperMocks
+func NewFactoryWrapperMocks(t *testing.T) *FactoryWrapperMocks {
+	ctrl := gomock.NewController(t)
+
+	return &FactoryWrapperMocks{
+		IObject1: mocks.NewMockIObject1(ctrl),
+		IObject2: mocks.NewMockIObject2(ctrl),
+	}
+}`,
+		},
+		{
+			Backend: mockBackendMockery,
+			WantImports: `import (
+	mainpkg "github.com/khevse/codegen/tests/mainpkg"
+	mocks "github.com/khevse/codegen/tests/mainpkg/mocks"
+	"testing"
+)`,
+			WantMocks: `// FactoryWrapper mocks
+type FactoryWrapperMocks struct {
+	IObject1 *mocks.IObject1
+	IObject2 *mocks.IObject2
+}
+
+// NewFactoryWrapperMocks return object FactoryWrapperMocks
+func NewFactoryWrapperMocks(t *testing.T) *FactoryWrapperMocks {
+	return &FactoryWrapperMocks{
+		IObject1: mocks.NewIObject1(t),
+		IObject2: mocks.NewIObject2(t),
+	}
+}`,
+		},
+		{
+			Backend: mockBackendCodegen,
+			WantImports: `import (
+	mainpkg "github.com/khevse/codegen/tests/mainpkg"
+	mocks "github.com/khevse/codegen/tests/mainpkg/mocks"
+	"testing"
+)`,
+			WantMocks: `// FactoryWrapper mocks
+type FactoryWrapperMocks struct {
+	IObject1 *mocks.IObject1Mock
+	IObject2 *mocks.IObject2Mock
+}
+
+// NewFactoryWrapperMocks return object FactoryWrapperMocks
+func NewFactoryWrapperMocks(t *testing.T) *FactoryWrapperMocks {
+	return &FactoryWrapperMocks{
+		IObject1: mocks.NewIObject1Mock(t),
+		IObject2: mocks.NewIObject2Mock(t),
+	}
+}`,
+		},
+	} {
+		t.Run(tc.Backend, func(t *testing.T) {
+			args := commandArgs{
+				interfaceType: "github.com/khevse/codegen/tests/mainpkg.IFactory=FactoryWrapper",
+				targetDir:     "./",
+				fileSuffix:    "_" + tc.Backend + "_generated",
+				mockPackage:   "github.com/khevse/codegen/tests/mainpkg/mocks",
+				mockBackend:   tc.Backend,
+			}
+			require.NoError(t, (&Command{args: args}).Execute())
+
+			wantFile := "wrapper_" + tc.Backend + "_generated.go"
+			defer func() {
+				require.NoError(t, os.Remove(wantFile))
+			}()
+
+			data, err := os.ReadFile(wantFile)
+			require.NoError(t, err)
+			require.Contains(t, string(data), tc.WantImports)
+			require.Contains(t, string(data), tc.WantMocks)
+		})
+	}
+
+	t.Run("unknown backend", func(t *testing.T) {
+		args := commandArgs{
+			interfaceType: "github.com/khevse/codegen/tests/mainpkg.IFactory=FactoryWrapper",
+			targetDir:     "./",
+			mockPackage:   "github.com/khevse/codegen/tests/mainpkg/mocks",
+			mockBackend:   "unknown",
+		}
+		require.EqualError(t, (&Command{args: args}).Execute(), "get mock backend: unknown mock backend: unknown")
+	})
+}
+
+func TestExecuteFilter(t *testing.T) {
+	args := commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/filteredpkg.IAccount=AccountWrapper",
+		targetDir:     "./",
+		fileSuffix:    "_filter_generated",
+		mockPackage:   "github.com/khevse/codegen/tests/mainpkg/mocks",
+		filter: filterpkg.Params{
+			Exclude: []string{"IAccount.Owner"},
+		},
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "wrapper_filter_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package object_test_wrapper
+
+import (
+	filteredpkg "github.com/khevse/codegen/tests/filteredpkg"
+	"testing"
+)
+
+// AccountWrapper mocks
+type AccountWrapperMocks struct {
+}
+
+// NewAccountWrapperMocks return object AccountWrapperMocks
+func NewAccountWrapperMocks(t *testing.T) *AccountWrapperMocks {
+	return &AccountWrapperMocks{}
+}
+
+/* AccountWrapper wrapper for type IAccount: IAccount . */
+type AccountWrapper struct {
+	mocks  AccountWrapperMocks
+	base   filteredpkg.IAccount
+	IDArg0 string
+}
+
+/* ID . */
+func (w *AccountWrapper) ID() (_ string) {
+	existsMock := false
+	if existsMock {
+		return w.IDArg0
+	}
+
+	return w.base.ID()
+}
+
+/* Owner . */
+func (w *AccountWrapper) Owner() (_ filteredpkg.IOwner) {
+	return w.base.Owner()
+}
+
+/* Refresh . */
+func (w *AccountWrapper) Refresh() (_ error) {
+	return w.base.Refresh()
+}
+
+// AccountWrapperBuilder wrapper builder
+type AccountWrapperBuilder struct {
+	object AccountWrapper
+}
+
+// SetBase set the base object with default behavior
+func (b *AccountWrapperBuilder) SetBase(val filteredpkg.IAccount) *AccountWrapperBuilder {
+	b.object.base = val
+	return b
+}
+
+// Build return wrapper object
+func (b *AccountWrapperBuilder) Build() *AccountWrapper {
+	return &b.object
+}
+
+// SetAllMocks set all mocks objects
+func (b *AccountWrapperBuilder) SetAllMocks(val *AccountWrapperMocks) *AccountWrapperBuilder {
+
+	return b
+}
+`,
+		string(data),
+	)
+}
+
+func TestExecuteFilterMatchesNothing(t *testing.T) {
+	args := commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/filteredpkg.IAccount=AccountWrapper",
+		targetDir:     "./",
+		fileSuffix:    "_matches_nothing_generated",
+		mockPackage:   "github.com/khevse/codegen/tests/mainpkg/mocks",
+		filter: filterpkg.Params{
+			Exclude: []string{"IAccount.Balance"},
+		},
+	}
+	require.EqualError(t, (&Command{args: args}).Execute(), "exclude rule matches nothing: IAccount.Balance")
+
+	// the file is not written
+	_, err := os.Stat("wrapper_matches_nothing_generated.go")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestGetAnnotatedArgsList(t *testing.T) {
+	t.Parallel()
+
+	pkgDir, err := filepath.Abs("./../../../tests/annotatedpkg")
+	require.NoError(t, err)
+
+	args := commandArgs{
+		mockBackend: mockBackendMinimock,
+		fileSuffix:  "_generated",
+		annotated:   "github.com/khevse/codegen/tests/...",
+	}
+
+	res, err := getAnnotatedArgsList(args)
+	require.NoError(t, err)
+	require.Equal(t,
+		[]commandArgs{
+			{
+				interfaceType: "github.com/khevse/codegen/tests/annotatedpkg.Factory=FactoryWrapper",
+				targetDir:     filepath.Join(pkgDir, "wrappers"),
+				mockPackage:   "github.com/khevse/codegen/tests/annotatedpkg/mocks",
+				mockBackend:   mockBackendCodegen,
+				fileSuffix:    "_generated",
+			},
+		},
+		res,
+	)
+}
+
+func TestExecuteAnnotated(t *testing.T) {
+	t.Parallel()
+
+	args := commandArgs{
+		check:     true,
+		annotated: "github.com/khevse/codegen/tests/annotatedpkg",
+	}
+
+	// the files are not generated yet, the check mode does not write them
+	err := (&Command{args: args}).Execute()
+	require.ErrorIs(t, err, filepkg.ErrOutdated)
+	require.ErrorContains(t, err, filepath.Join("tests", "annotatedpkg", "wrappers", "factory_wrapper.go"))
+	require.NoFileExists(t, "./../../../tests/annotatedpkg/wrappers/factory_wrapper.go")
+
+	t.Run("required flags", func(t *testing.T) {
+		err := (&Command{args: commandArgs{}}).Execute()
+		require.EqualError(t, err, "flags --interface-type, --target-dir and --mock-package are required without --annotated")
+	})
+}
@@ -11,13 +11,23 @@ import (
 	"github.com/khevse/codegen/internal/pkg/templatepkg"
 )
 
+const templateFile = "file.tmpl"
+
 //go:embed file.tmpl
 var content embed.FS
 
+// defaultTemplate returns the embedded template.
+func defaultTemplate() ([]byte, error) {
+	return content.ReadFile(templateFile)
+}
+
 type generator struct {
-	Package    string
-	Imports    astpkg.ImportList
-	ObjectSpec objectSpec
+	Package     string
+	Imports     astpkg.ImportList
+	ObjectSpec  objectSpec
+	MockBackend mockBackend
+	// Template is the path of the custom template file, optional
+	Template string
 }
 
 func (g generator) Generate(w io.Writer) error {
@@ -32,14 +42,16 @@ func (g generator) Generate(w io.Writer) error {
 	})
 
 	params := templatepkg.ExecuteTemplateParams{
-		Writer:       w,
-		FS:           content,
-		TemplateFile: "file.tmpl",
+		Writer:             w,
+		FS:                 content,
+		TemplateFile:       templateFile,
+		CustomTemplateFile: g.Template,
 		Data: map[string]any{
-			"package":    g.Package,
-			"imports":    g.Imports,
-			"objectSpec": g.ObjectSpec,
-			"appInfo":    application.GetInfo(),
+			"package":     g.Package,
+			"imports":     g.Imports,
+			"objectSpec":  g.ObjectSpec,
+			"mockBackend": g.MockBackend,
+			"appInfo":     application.GetInfo(),
 		},
 		Format: true,
 	}
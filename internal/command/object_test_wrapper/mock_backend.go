@@ -0,0 +1,71 @@
+package object_test_wrapper
+
+import (
+	"fmt"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+)
+
+const (
+	mockBackendMinimock = "minimock"
+	mockBackendGomock   = "gomock"
+	mockBackendMockery  = "mockery"
+	mockBackendCodegen  = "codegen"
+)
+
+// mockBackend describes how the wrapper creates the mocks of the mock generator:
+// the mock type of the interface IObject is <TypePrefix>IObject<TypeSuffix>,
+// the constructor is New<mock type>(<ControllerVar>).
+type mockBackend struct {
+	// Imports are required by the controller.
+	Imports astpkg.ImportList
+	// Controller is the statement which creates the controller, it is empty if the mocks
+	// are created by the test object.
+	Controller    string
+	ControllerVar string
+	TypePrefix    string
+	TypeSuffix    string
+}
+
+func (b mockBackend) TypeName(interfaceName string) string {
+	return b.TypePrefix + interfaceName + b.TypeSuffix
+}
+
+func getMockBackend(name string) (mockBackend, error) {
+	switch name {
+	case "", mockBackendMinimock:
+		return mockBackend{
+			Imports:       astpkg.ImportList{astpkg.NewImport("minimock", "github.com/gojuno/minimock/v3")},
+			Controller:    "mc := minimock.NewController(t)",
+			ControllerVar: "mc",
+			TypePrefix:    "",
+			TypeSuffix:    "Mock",
+		}, nil
+	case mockBackendGomock:
+		return mockBackend{
+			Imports:       astpkg.ImportList{astpkg.NewImport("gomock", "go.uber.org/mock/gomock")},
+			Controller:    "ctrl := gomock.NewController(t)",
+			ControllerVar: "ctrl",
+			TypePrefix:    "Mock",
+			TypeSuffix:    "",
+		}, nil
+	case mockBackendMockery:
+		return mockBackend{
+			Imports:       nil,
+			Controller:    "",
+			ControllerVar: "t",
+			TypePrefix:    "",
+			TypeSuffix:    "",
+		}, nil
+	case mockBackendCodegen:
+		return mockBackend{
+			Imports:       nil,
+			Controller:    "",
+			ControllerVar: "t",
+			TypePrefix:    "",
+			TypeSuffix:    "Mock",
+		}, nil
+	default:
+		return mockBackend{}, fmt.Errorf("unknown mock backend: %s", name)
+	}
+}
@@ -0,0 +1,98 @@
+package object_test_wrapper
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/filterpkg"
+)
+
+const defaultFileName = "wrapper.go"
+
+// Params are the parameters of the wrapper generation without the command line.
+type Params struct {
+	// InterfaceType is the interface of the wrapped object: <package>.<InterfaceName> or <package>.<InterfaceName>=<WrapperName>
+	InterfaceType string
+	// TargetDir is the dir of the generated file
+	TargetDir string
+	// FileName is the name of the generated file, wrapper.go by default
+	FileName string
+	// MockPackage is the package of the mocks
+	MockPackage string
+	// MockBackend is the mocks generator: minimock (default), gomock, mockery, codegen
+	MockBackend string
+	// Filter are the include and exclude rules of the methods, the excluded methods call the base object, optional
+	Filter filterpkg.Params
+	// Template is the path of the custom template file, optional
+	Template string
+	// Cache is the cache of the parsed packages, optional
+	Cache *astpkg.PackageCache
+}
+
+// Spec is the specification of the generated file: the data of the template and the file path.
+type Spec struct {
+	FilePath string
+	generator
+}
+
+// NewSpec parses the package of the interface and builds the specification of the wrapper.
+// The rules of the filter which have not matched any method are returned as the error.
+func NewSpec(params Params) (*Spec, error) {
+	fileName := params.FileName
+	if fileName == "" {
+		fileName = defaultFileName
+	}
+
+	methodFilter, err := filterpkg.NewMethodFilter(params.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("new method filter: %w", err)
+	}
+
+	spec, err := newSpec(commandArgs{
+		interfaceType: params.InterfaceType,
+		targetDir:     params.TargetDir,
+		mockPackage:   params.MockPackage,
+		mockBackend:   params.MockBackend,
+		templateFile:  params.Template,
+		methodFilter:  methodFilter,
+		packageCache:  params.Cache,
+	}, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := methodFilter.Err(); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+func newSpec(args commandArgs, fileName string) (*Spec, error) {
+	targetDir, err := filepath.Abs(args.targetDir)
+	if err != nil {
+		return nil, fmt.Errorf("get target dir full path: %w", err)
+	}
+
+	backend, err := getMockBackend(args.mockBackend)
+	if err != nil {
+		return nil, fmt.Errorf("get mock backend: %w", err)
+	}
+
+	importList, objectSpec, err := prepareObjectSpec(args)
+	if err != nil {
+		return nil, fmt.Errorf("prepare object specification: %w", err)
+	}
+
+	return &Spec{
+		FilePath: filepath.Join(targetDir, fileName),
+		generator: generator{
+			Package:     filepath.Base(targetDir),
+			Imports:     importList,
+			ObjectSpec:  *objectSpec,
+			MockBackend: backend,
+			Template:    args.templateFile,
+		},
+	}, nil
+}
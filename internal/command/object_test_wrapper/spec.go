@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/filterpkg"
 	"github.com/samber/lo"
 )
 
@@ -22,6 +23,8 @@ type methodSpec struct {
 	Comment string
 	Params  []field
 	Results []field
+	// Excluded is the method excluded by the filter, it calls the base object without the mocks
+	Excluded bool
 }
 
 type objectSpecField struct {
@@ -34,24 +37,40 @@ type objectSpecField struct {
 
 type objectSpec struct {
 	Name               string
+	TypeParams         string
+	TypeArgs           string
 	Comment            string
 	Methods            []methodSpec
 	Fields             []objectSpecField
 	BaseObjectTypeName string
 }
 
+// HasMocks reports whether the wrapper has at least one mock object.
+func (s objectSpec) HasMocks() bool {
+	return lo.ContainsBy(s.Fields, func(item objectSpecField) bool {
+		return item.MockTypeName != ""
+	})
+}
+
 func newObjectSpec(
 	interfaceType argInterfaceType,
 	mockPackageName string,
+	backend mockBackend,
 	typeDecl *astpkg.TypeDecl,
 	imports astpkg.ImportList,
+	methodFilter *filterpkg.MethodFilter,
 ) (*objectSpec, error) {
 	castedType, ok := astpkg.CastToType[astpkg.InterfaceType](typeDecl.Type)
 	if !ok {
 		return nil, errors.New("type is not interface")
 	}
 
-	for _, item := range castedType.Methods {
+	methods, err := astpkg.GetInterfaceMethods(castedType)
+	if err != nil {
+		return nil, fmt.Errorf("get interface methods: %w", err)
+	}
+
+	for _, item := range methods {
 		err := astpkg.InspectType(item.Type, func(t astpkg.Type) error {
 			return astpkg.ReplaceImportAliasByImportPath(t, imports)
 		})
@@ -60,6 +79,12 @@ func newObjectSpec(
 		}
 	}
 
+	for _, item := range typeDecl.TypeParams {
+		if err := astpkg.ReplaceImportAliasByImportPath(item.Type, imports); err != nil {
+			return nil, fmt.Errorf("replace type param imports(%s): %w", item.Name, err)
+		}
+	}
+
 	objectPackage, ok := imports.GetByPath(interfaceType.Package)
 	if !ok {
 		return nil, fmt.Errorf("get object type package: %s", interfaceType.Package)
@@ -71,11 +96,13 @@ func newObjectSpec(
 	} else {
 		baseObjectTypeName = fmt.Sprintf("%s.%s", objectPackage.Alias, interfaceType.TypeName)
 	}
+	typeArgs := astpkg.TypeParamsArgs(typeDecl.TypeParams)
+	baseObjectTypeName += typeArgs
 
-	methodList := make([]methodSpec, 0, len(castedType.Methods))
+	methodList := make([]methodSpec, 0, len(methods))
 	objectSpecFieldList := make([]objectSpecField, 0)
 
-	for _, item := range castedType.Methods {
+	for _, item := range methods {
 		if !astpkg.IsExported(item.Name) {
 			continue
 		}
@@ -91,6 +118,7 @@ func newObjectSpec(
 				methodName:      item.Name,
 				filedList:       filedList,
 				mockPackageName: mockPackageName,
+				mockBackend:     backend,
 				imports:         imports,
 			}
 		}
@@ -108,13 +136,17 @@ func newObjectSpec(
 		}
 
 		method := methodSpec{
-			Name:    item.Name,
-			Comment: fmt.Sprintf("%s .", item.Name),
-			Params:  params,
-			Results: results,
+			Name:     item.Name,
+			Comment:  fmt.Sprintf("%s .", item.Name),
+			Params:   params,
+			Results:  results,
+			Excluded: !methodFilter.Match(interfaceType.TypeName, item.Name, item.Directives),
 		}
 
 		methodList = append(methodList, method)
+		if method.Excluded {
+			continue
+		}
 
 		for _, item := range results {
 			objectSpecFieldList = append(objectSpecFieldList, newObjectSpecField(item))
@@ -128,6 +160,8 @@ func newObjectSpec(
 
 	return &objectSpec{
 		Name:               interfaceType.WrapperName,
+		TypeParams:         astpkg.TypeParamsDecl(typeDecl.TypeParams),
+		TypeArgs:           typeArgs,
 		Comment:            objectSpecComment,
 		Methods:            methodList,
 		Fields:             objectSpecFieldList,
@@ -140,6 +174,7 @@ type newFieldListParams struct {
 	methodName      string
 	filedList       []*astpkg.Field
 	mockPackageName string
+	mockBackend     mockBackend
 	imports         astpkg.ImportList
 }
 
@@ -162,13 +197,13 @@ func newFieldsList(params newFieldListParams) ([]field, error) {
 		if castedType, ok := astpkg.CastToType[astpkg.Ident](item.Type); ok {
 			if _, ok := astpkg.CastToType[astpkg.InterfaceType](castedType.Type); ok {
 				objectSpecName = castedType.Name
-				mockTypeName = castedType.Name + "Mock"
+				mockTypeName = params.mockBackend.TypeName(castedType.Name)
 				mockPackage = mockPackageAlias
 			}
 		} else if castedType, ok := astpkg.CastToType[astpkg.SelectorExpr](item.Type); ok {
 			if _, ok := astpkg.CastToType[astpkg.InterfaceType](castedType.Type); ok {
 				objectSpecName = castedType.Name
-				mockTypeName = castedType.Name + "Mock"
+				mockTypeName = params.mockBackend.TypeName(castedType.Name)
 				mockPackage = mockPackageAlias
 			}
 		}
@@ -0,0 +1,120 @@
+package package_inspector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/command"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+type commandArgs struct {
+	pkgName string
+	format  string
+
+	packageCache *astpkg.PackageCache
+}
+
+type Command struct {
+	args   commandArgs
+	output io.Writer
+}
+
+// New returns the command writing the parsed package model to stdout.
+func New() *Command {
+	return &Command{
+		output: os.Stdout,
+	}
+}
+
+func (c *Command) Name() string {
+	return "inspect"
+}
+
+func (c *Command) ShortName() string {
+	return "insp"
+}
+
+// SetPackageCache sets the cache of the parsed packages shared between the commands.
+func (c *Command) SetPackageCache(cache *astpkg.PackageCache) {
+	c.args.packageCache = cache
+}
+
+func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
+	const (
+		flagPackage = "package"
+		flagFormat  = "format"
+	)
+
+	flagSetter.Flags().StringVarP(
+		&c.args.pkgName,
+		flagPackage,
+		"k",
+		"",
+		"package for inspection. Example: github.com/khevse/codegen/tests/mainpkg",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.format,
+		flagFormat,
+		"f",
+		formatJSON,
+		fmt.Sprintf("output format: %s, %s", formatJSON, formatYAML),
+	)
+
+	if err := flagSetter.MarkFlagRequired(flagPackage); err != nil {
+		return fmt.Errorf("mark flag as required(%s): %w", flagPackage, err)
+	}
+
+	return nil
+}
+
+func (c *Command) Execute() error {
+	pkg, err := c.args.packageCache.ParsePackage(c.args.pkgName)
+	if err != nil {
+		return fmt.Errorf("parse package(%s): %w", c.args.pkgName, err)
+	}
+
+	data, err := encode(c.args.format, astpkg.NewPackageSchema(pkg))
+	if err != nil {
+		return fmt.Errorf("encode package: %w", err)
+	}
+
+	if _, err := c.output.Write(data); err != nil {
+		return fmt.Errorf("write package: %w", err)
+	}
+
+	return nil
+}
+
+func encode(format string, schema astpkg.PackageSchema) ([]byte, error) {
+	switch format {
+	case formatJSON:
+		data, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case formatYAML:
+		buf := bytes.NewBuffer(nil)
+		encoder := yaml.NewEncoder(buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(schema); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown format: %s", format)
+	}
+}
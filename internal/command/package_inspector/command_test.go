@@ -0,0 +1,159 @@
+package package_inspector
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testPackage = "github.com/khevse/codegen/internal/command/package_inspector/testdata/inspectpkg"
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	dir, err := filepath.Abs("./testdata/inspectpkg")
+	require.NoError(t, err)
+
+	execute := func(format string) (string, error) {
+		output := bytes.NewBuffer(nil)
+
+		c := New()
+		c.args.pkgName = testPackage
+		c.args.format = format
+		c.output = output
+
+		err := c.Execute()
+		return output.String(), err
+	}
+
+	t.Run("yaml", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := execute(formatYAML)
+		require.NoError(t, err)
+
+		want := strings.ReplaceAll(`path: github.com/khevse/codegen/internal/command/package_inspector/testdata/inspectpkg
+dir: {{DIR}}
+imports:
+  - alias: io
+    path: io
+types:
+  - name: Reader
+    package: inspectpkg
+    package_path: github.com/khevse/codegen/internal/command/package_inspector/testdata/inspectpkg
+    comment: Reader reads the data.
+    directives:
+      - name: interface
+        args:
+          name: IReader
+    type:
+      kind: struct
+      expr: struct{r io.Reader}
+      fields:
+        - name: r
+          type:
+            kind: selector
+            expr: io.Reader
+            package_path: io
+            name: Reader
+            underlying:
+              kind: interface
+              expr: interface{Read(p []byte) (n int, err error)}
+              methods:
+                - name: Read
+                  type:
+                    kind: func
+                    expr: func(p []byte) (n int, err error)
+                    params:
+                      - name: p
+                        type:
+                          kind: array
+                          expr: '[]byte'
+                          elem:
+                            kind: ident
+                            expr: byte
+                            name: byte
+                    results:
+                      - name: "n"
+                        type:
+                          kind: ident
+                          expr: int
+                          name: int
+                      - name: err
+                        type:
+                          kind: ident
+                          expr: error
+                          name: error
+funcs:
+  - name: Read
+    receiver: Reader
+    pointer_receiver: true
+    comment: Read reads the data.
+    params:
+      - name: p
+        type:
+          kind: array
+          expr: '[]byte'
+          elem:
+            kind: ident
+            expr: byte
+            name: byte
+    results:
+      - type:
+          kind: ident
+          expr: int
+          name: int
+      - type:
+          kind: ident
+          expr: error
+          name: error
+promoted_funcs: []
+`, "{{DIR}}", dir)
+		require.Empty(t, cmp.Diff(want, got))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		gotJSON, err := execute(formatJSON)
+		require.NoError(t, err)
+		gotYAML, err := execute(formatYAML)
+		require.NoError(t, err)
+
+		// both formats contain the same schema
+		var fromJSON, fromYAML astpkg.PackageSchema
+		require.NoError(t, json.Unmarshal([]byte(gotJSON), &fromJSON))
+		require.NoError(t, yaml.Unmarshal([]byte(gotYAML), &fromYAML))
+		require.Empty(t, cmp.Diff(fromYAML, fromJSON))
+		require.Equal(t, testPackage, fromJSON.Path)
+		require.Equal(t, dir, fromJSON.Dir)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := execute("xml")
+		require.EqualError(t, err, "encode package: unknown format: xml")
+	})
+
+	t.Run("unknown package", func(t *testing.T) {
+		t.Parallel()
+
+		output := bytes.NewBuffer(nil)
+
+		c := New()
+		c.args.pkgName = testPackage + "/unknown"
+		c.args.format = formatJSON
+		c.output = output
+
+		require.ErrorContains(t, c.Execute(), "parse package("+testPackage+"/unknown)")
+		require.Empty(t, output.String())
+	})
+}
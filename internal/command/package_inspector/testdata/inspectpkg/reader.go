@@ -0,0 +1,15 @@
+package inspectpkg
+
+import "io"
+
+// Reader reads the data.
+//
+//codegen:interface name=IReader
+type Reader struct {
+	r io.Reader
+}
+
+// Read reads the data.
+func (r *Reader) Read(p []byte) (int, error) {
+	return r.r.Read(p)
+}
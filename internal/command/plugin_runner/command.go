@@ -0,0 +1,180 @@
+package plugin_runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/command"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+	"github.com/khevse/codegen/internal/pkg/pluginpkg"
+	"github.com/samber/lo"
+)
+
+type commandArgs struct {
+	packages  []string
+	targetDir string
+	args      map[string]string
+	check     bool
+	dryRun    bool
+	output    string
+
+	packageCache *astpkg.PackageCache
+}
+
+// Command executes the external generator: the parsed packages and the arguments are passed
+// to the plugin, the files returned by the plugin are written.
+type Command struct {
+	args   commandArgs
+	plugin pluginpkg.Plugin
+}
+
+// New returns the command of the plugin.
+func New(plugin pluginpkg.Plugin) *Command {
+	return &Command{
+		plugin: plugin,
+	}
+}
+
+// Discover returns the commands of the plugins found in the PATH list,
+// the plugins with the names of the commands are skipped.
+func Discover(pathList string, commands []command.Command) []command.Command {
+	res := make([]command.Command, 0)
+	for _, plugin := range pluginpkg.Find(pathList) {
+		if _, ok := command.Find(commands, plugin.Name); ok {
+			continue
+		}
+
+		res = append(res, New(plugin))
+	}
+
+	return res
+}
+
+func (c *Command) Name() string {
+	return c.plugin.Name
+}
+
+func (c *Command) ShortName() string {
+	return c.plugin.Name
+}
+
+// SetPackageCache sets the cache of the parsed packages shared between the commands.
+func (c *Command) SetPackageCache(cache *astpkg.PackageCache) {
+	c.args.packageCache = cache
+}
+
+func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
+	const (
+		flagPackage   = "package"
+		flagTargetDir = "target-dir"
+		flagArg       = "arg"
+	)
+
+	flagSetter.Flags().StringSliceVarP(
+		&c.args.packages,
+		flagPackage,
+		"k",
+		nil,
+		"packages passed to the plugin. Examples: <package>; <package1>,<package2>",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.targetDir,
+		flagTargetDir,
+		"p",
+		"",
+		"target dir for the generated files",
+	)
+	flagSetter.Flags().StringToStringVarP(
+		&c.args.args,
+		flagArg,
+		"a",
+		nil,
+		"arguments passed to the plugin. Examples: <name>=<value>; <name1>=<value1>,<name2>=<value2>",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.check,
+		command.CheckFlag,
+		"",
+		false,
+		"check that the generated files are up to date without writing them, the differences are printed",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.dryRun,
+		command.DryRunFlag,
+		"",
+		false,
+		"list the files which would be written or changed without writing them",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.output,
+		command.OutputFlag,
+		"",
+		"",
+		"print the generated code to stdout instead of writing the files, the only supported value is -",
+	)
+
+	if err := flagSetter.MarkFlagRequired(flagTargetDir); err != nil {
+		return fmt.Errorf("mark flag as required(%s): %w", flagTargetDir, err)
+	}
+
+	return nil
+}
+
+func (c *Command) Execute() error {
+	writer, err := filepkg.NewWriter(filepkg.WriterParams{
+		Check:      c.args.check,
+		DryRun:     c.args.dryRun,
+		OutputFile: c.args.output,
+		Output:     os.Stdout,
+	})
+	if err != nil {
+		return fmt.Errorf("new writer: %w", err)
+	}
+
+	targetDir, err := filepath.Abs(c.args.targetDir)
+	if err != nil {
+		return fmt.Errorf("get target dir full path: %w", err)
+	}
+
+	packages := make([]astpkg.PackageSchema, 0, len(c.args.packages))
+	for _, pkgName := range c.args.packages {
+		pkg, err := c.args.packageCache.ParsePackage(pkgName)
+		if err != nil {
+			return fmt.Errorf("parse package(%s): %w", pkgName, err)
+		}
+
+		packages = append(packages, astpkg.NewPackageSchema(pkg))
+	}
+
+	res, err := c.plugin.Run(pluginpkg.Request{
+		Version:   pluginpkg.Version,
+		Command:   c.plugin.Name,
+		TargetDir: targetDir,
+		Args:      lo.Ternary(c.args.args == nil, map[string]string{}, c.args.args),
+		Packages:  packages,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the paths are checked before writing, so the invalid response does not write any file
+	filePathList := make([]string, 0, len(res.Files))
+	for _, file := range res.Files {
+		filePath, err := file.ResolvePath(targetDir)
+		if err != nil {
+			return err
+		}
+
+		filePathList = append(filePathList, filePath)
+	}
+
+	for i, file := range res.Files {
+		if err := writer.WriteFile(filePathList[i], []byte(file.Content)); err != nil {
+			return err
+		}
+	}
+
+	return writer.Err()
+}
@@ -0,0 +1,111 @@
+package plugin_runner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/khevse/codegen/internal/command/interface_creator"
+	"github.com/khevse/codegen/internal/pkg/command"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+	"github.com/khevse/codegen/internal/pkg/pluginpkg"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugins are shell scripts")
+	}
+
+	dir := t.TempDir()
+	for _, name := range []string{"codegen-interface", "codegen-i", "codegen-logger"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0o755))
+	}
+
+	commands := Discover(dir, []command.Command{interface_creator.New()})
+
+	names := lo.Map(commands, func(cmd command.Command, _ int) string { return cmd.Name() })
+	require.Equal(t, []string{"logger"}, names)
+}
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugins are shell scripts")
+	}
+
+	dir := t.TempDir()
+	requestFile := filepath.Join(dir, "request.json")
+	pluginFile := filepath.Join(dir, "codegen-logger")
+	require.NoError(t, os.WriteFile(pluginFile, []byte(`#!/bin/sh
+cat > `+requestFile+`
+printf '%s' '{"files":[{"path":"logger.go","content":"package pkg\n"}]}'
+`), 0o755))
+
+	targetDir := filepath.Join(dir, "target")
+	require.NoError(t, os.Mkdir(targetDir, 0o755))
+
+	newCommand := func() *Command {
+		c := New(pluginpkg.Plugin{Name: "logger", Path: pluginFile})
+		c.args.packages = []string{"github.com/khevse/codegen/tests/mainpkg/childpkg"}
+		c.args.targetDir = targetDir
+		c.args.args = map[string]string{"name": "Logger"}
+		return c
+	}
+
+	c := newCommand()
+	c.args.check = true
+	require.ErrorIs(t, c.Execute(), filepkg.ErrOutdated)
+	require.NoFileExists(t, filepath.Join(targetDir, "logger.go"))
+
+	require.NoError(t, newCommand().Execute())
+
+	data, err := os.ReadFile(filepath.Join(targetDir, "logger.go"))
+	require.NoError(t, err)
+	require.Equal(t, "package pkg\n", string(data))
+
+	data, err = os.ReadFile(requestFile)
+	require.NoError(t, err)
+
+	var req pluginpkg.Request
+	require.NoError(t, json.Unmarshal(data, &req))
+	require.Equal(t, pluginpkg.Version, req.Version)
+	require.Equal(t, "logger", req.Command)
+	require.Equal(t, targetDir, req.TargetDir)
+	require.Equal(t, map[string]string{"name": "Logger"}, req.Args)
+	require.Len(t, req.Packages, 1)
+	require.Equal(t, "github.com/khevse/codegen/tests/mainpkg/childpkg", req.Packages[0].Path)
+	require.NotEmpty(t, req.Packages[0].Types)
+}
+
+func TestExecuteOutsideTargetDir(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugins are shell scripts")
+	}
+
+	dir := t.TempDir()
+	pluginFile := filepath.Join(dir, "codegen-logger")
+	require.NoError(t, os.WriteFile(pluginFile, []byte(`#!/bin/sh
+cat > /dev/null
+printf '%s' '{"files":[{"path":"logger.go","content":"package pkg\n"},{"path":"../logger.go","content":"package pkg\n"}]}'
+`), 0o755))
+
+	targetDir := filepath.Join(dir, "target")
+	require.NoError(t, os.Mkdir(targetDir, 0o755))
+
+	c := New(pluginpkg.Plugin{Name: "logger", Path: pluginFile})
+	c.args.targetDir = targetDir
+	require.EqualError(t, c.Execute(), "plugin returned the file outside the target dir: ../logger.go")
+
+	// the files are not written
+	require.NoFileExists(t, filepath.Join(targetDir, "logger.go"))
+	require.NoFileExists(t, filepath.Join(dir, "logger.go"))
+}
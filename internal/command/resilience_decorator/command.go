@@ -0,0 +1,285 @@
+package resilience_decorator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/command"
+	"github.com/khevse/codegen/internal/pkg/filepkg"
+	"github.com/khevse/codegen/internal/pkg/interfacepkg"
+	"github.com/khevse/codegen/internal/pkg/stringspkg"
+	"github.com/samber/lo"
+)
+
+type commandArgs struct {
+	interfaceType    string
+	targetDir        string
+	fileSuffix       string
+	maxAttempts      int
+	backoff          time.Duration
+	maxBackoff       time.Duration
+	timeout          time.Duration
+	methodTimeouts   map[string]string
+	breakerThreshold int
+	breakerTimeout   time.Duration
+	check            bool
+	dryRun           bool
+	output           string
+	templateFile     string
+
+	packageCache *astpkg.PackageCache
+}
+
+type Command struct {
+	args commandArgs
+}
+
+func New() *Command {
+	return new(Command)
+}
+
+func (c *Command) Name() string {
+	return "resilience-decorator"
+}
+
+func (c *Command) ShortName() string {
+	return "rd"
+}
+
+// SetPackageCache sets the cache of the parsed packages shared between the commands.
+func (c *Command) SetPackageCache(cache *astpkg.PackageCache) {
+	c.args.packageCache = cache
+}
+
+// DefaultTemplate returns the default template of the generated file.
+func (c *Command) DefaultTemplate() ([]byte, error) {
+	return defaultTemplate()
+}
+
+func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
+	const (
+		flagInterfaceType    = "interface-type"
+		flagTargetDir        = "target-dir"
+		flagFileSuffix       = "suffix"
+		flagMaxAttempts      = "max-attempts"
+		flagBackoff          = "backoff"
+		flagMaxBackoff       = "max-backoff"
+		flagTimeout          = "timeout"
+		flagMethodTimeout    = "method-timeout"
+		flagBreakerThreshold = "breaker-threshold"
+		flagBreakerTimeout   = "breaker-timeout"
+		flagCheck            = "check"
+		flagDryRun           = "dry-run"
+		flagOutput           = "output"
+		flagTemplateFile     = "template"
+	)
+
+	flagSetter.Flags().StringVarP(
+		&c.args.interfaceType,
+		flagInterfaceType,
+		"i",
+		"",
+		"interface type for decorator generation. Examples: <package>.<InterfaceName>; <package>.<InterfaceName>=<DecoratorName>; <package>.<InterfaceName1>,<package>.<InterfaceName2>=<DecoratorName2>",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.targetDir,
+		flagTargetDir,
+		"p",
+		"",
+		"target dir for the decorators",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.fileSuffix,
+		flagFileSuffix,
+		"",
+		"",
+		"result file suffix",
+	)
+	flagSetter.Flags().IntVarP(
+		&c.args.maxAttempts,
+		flagMaxAttempts,
+		"",
+		3,
+		"default number of the attempts of the call, 1 disables the retries",
+	)
+	flagSetter.Flags().DurationVarP(
+		&c.args.backoff,
+		flagBackoff,
+		"",
+		100*time.Millisecond,
+		"default delay before the second attempt, it is doubled for each next attempt",
+	)
+	flagSetter.Flags().DurationVarP(
+		&c.args.maxBackoff,
+		flagMaxBackoff,
+		"",
+		2*time.Second,
+		"default maximum delay between the attempts, 0 disables the limit",
+	)
+	flagSetter.Flags().DurationVarP(
+		&c.args.timeout,
+		flagTimeout,
+		"",
+		0,
+		"default timeout of each attempt, 0 disables the timeout",
+	)
+	flagSetter.Flags().StringToStringVarP(
+		&c.args.methodTimeouts,
+		flagMethodTimeout,
+		"",
+		nil,
+		"default timeouts of the methods. Examples: <Method>=<timeout>; <Method1>=1s,<Method2>=500ms",
+	)
+	flagSetter.Flags().IntVarP(
+		&c.args.breakerThreshold,
+		flagBreakerThreshold,
+		"",
+		5,
+		"default number of the consecutive failed attempts opening the circuit breaker, 0 disables the circuit breaker",
+	)
+	flagSetter.Flags().DurationVarP(
+		&c.args.breakerTimeout,
+		flagBreakerTimeout,
+		"",
+		30*time.Second,
+		"default time the circuit breaker is open",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.check,
+		flagCheck,
+		"",
+		false,
+		"check that the generated files are up to date without writing them, the differences are printed",
+	)
+	flagSetter.Flags().BoolVarP(
+		&c.args.dryRun,
+		flagDryRun,
+		"",
+		false,
+		"list the files which would be written or changed without writing them",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.output,
+		flagOutput,
+		"",
+		"",
+		"print the generated code to stdout instead of writing the files, the only supported value is -",
+	)
+	flagSetter.Flags().StringVarP(
+		&c.args.templateFile,
+		flagTemplateFile,
+		"",
+		"",
+		"custom template file used instead of the default template",
+	)
+
+	for _, flagName := range []string{flagInterfaceType, flagTargetDir} {
+		if err := flagSetter.MarkFlagRequired(flagName); err != nil {
+			return fmt.Errorf("mark flag as required(%s): %w", flagName, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Command) Execute() error {
+	writer, err := filepkg.NewWriter(filepkg.WriterParams{
+		Check:      c.args.check,
+		DryRun:     c.args.dryRun,
+		OutputFile: c.args.output,
+		Output:     os.Stdout,
+	})
+	if err != nil {
+		return fmt.Errorf("new writer: %w", err)
+	}
+
+	targetDir, err := filepath.Abs(c.args.targetDir)
+	if err != nil {
+		return fmt.Errorf("get target dir full path: %w", err)
+	}
+
+	fileList, err := prepareFileList(c.args)
+	if err != nil {
+		return fmt.Errorf("prepare decorators specifications: %w", err)
+	}
+
+	for _, item := range fileList {
+		fileName := fmt.Sprintf("%s%s.go", stringspkg.ToSnakeCase(item.Decorator.Name), c.args.fileSuffix)
+		filePath := filepath.Join(targetDir, fileName)
+
+		g := generator{
+			Package:   filepath.Base(targetDir),
+			Imports:   item.Imports,
+			Decorator: item.Decorator,
+			Template:  c.args.templateFile,
+		}
+
+		buf := bytes.NewBuffer(nil)
+		if err := g.Generate(buf); err != nil {
+			return fmt.Errorf("generate decorator(%s): %w", item.Decorator.Name, err)
+		}
+
+		if err := writer.WriteFile(filePath, buf.Bytes()); err != nil {
+			return fmt.Errorf("write decorator(%s): %w", item.Decorator.Name, err)
+		}
+	}
+
+	return writer.Err()
+}
+
+type decoratorFile struct {
+	Imports   astpkg.ImportList
+	Decorator decoratorSpec
+}
+
+func prepareFileList(args commandArgs) ([]decoratorFile, error) {
+	options, err := newOptions(args)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaceTypeList, err := interfacepkg.ParseInterfaceTypeList(args.interfaceType, "Resilient")
+	if err != nil {
+		return nil, fmt.Errorf("parse interface types: %w", err)
+	}
+
+	fileList, err := interfacepkg.PrepareFileList(interfacepkg.PrepareFileListParams{
+		InterfaceTypes: interfaceTypeList,
+		TargetDir:      args.targetDir,
+		Imports:        []string{"context", "errors", "sync", "time"},
+		Reserved:       reservedNames,
+		Cache:          args.packageCache,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timeouts := make(map[string]struct{})
+	res := make([]decoratorFile, 0, len(fileList))
+	for _, item := range fileList {
+		res = append(res, decoratorFile{
+			Imports: item.Imports,
+			Decorator: newDecoratorSpec(newDecoratorSpecParams{
+				spec:     item.Spec,
+				options:  options,
+				timeouts: timeouts,
+			}),
+		})
+	}
+
+	unmatched := lo.Filter(lo.Keys(options.MethodTimeouts), func(item string, _ int) bool {
+		_, ok := timeouts[item]
+		return !ok
+	})
+	if len(unmatched) != 0 {
+		slices.Sort(unmatched)
+		return nil, fmt.Errorf("methods with timeouts are not found or have no context and error: %v", unmatched)
+	}
+
+	return res, nil
+}
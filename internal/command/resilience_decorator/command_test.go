@@ -0,0 +1,279 @@
+package resilience_decorator
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute(t *testing.T) {
+	args := commandArgs{
+		interfaceType:    "github.com/khevse/codegen/tests/decoratorpkg.IService",
+		targetDir:        "./",
+		fileSuffix:       "_generated",
+		maxAttempts:      3,
+		backoff:          100 * time.Millisecond,
+		maxBackoff:       2 * time.Second,
+		methodTimeouts:   map[string]string{"Login": "1500ms"},
+		breakerThreshold: 5,
+		breakerTimeout:   30 * time.Second,
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "i_service_resilient_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package resilience_decorator
+
+import (
+	context "context"
+	errors "errors"
+	decoratorpkg "github.com/khevse/codegen/tests/decoratorpkg"
+	sync "sync"
+	time "time"
+)
+
+var _ decoratorpkg.IService = (*IServiceResilient)(nil)
+
+// ErrIServiceResilientOpen is returned by IServiceResilient if the circuit breaker is open.
+var ErrIServiceResilientOpen = errors.New("IService: circuit breaker is open")
+
+// IServiceResilientOptions are the options of IServiceResilient.
+type IServiceResilientOptions struct {
+	// MaxAttempts is the number of the attempts of the call, 1 disables the retries.
+	MaxAttempts int
+	// Backoff is the delay before the second attempt, it is doubled for each next attempt up to MaxBackoff,
+	// 0 MaxBackoff disables the limit.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Timeout is the timeout of each attempt, 0 disables the timeout.
+	Timeout time.Duration
+	// MethodTimeouts are the timeouts of the methods used instead of Timeout.
+	MethodTimeouts map[string]time.Duration
+	// BreakerThreshold is the number of the consecutive failed attempts opening the circuit breaker,
+	// 0 disables the circuit breaker. The attempts failed after the context of the call is done are not counted.
+	BreakerThreshold int
+	// BreakerTimeout is the time the circuit breaker is open, then one probe call is allowed: its success closes
+	// the circuit breaker, its failure opens it again, the other calls are rejected until the probe call is done.
+	BreakerTimeout time.Duration
+}
+
+// DefaultIServiceResilientOptions returns the options set by the generator.
+func DefaultIServiceResilientOptions() IServiceResilientOptions {
+	return IServiceResilientOptions{
+		MaxAttempts: 3,
+		Backoff:     100 * time.Millisecond,
+		MaxBackoff:  2 * time.Second,
+		Timeout:     0,
+		MethodTimeouts: map[string]time.Duration{
+			"Login": 1500 * time.Millisecond,
+		},
+		BreakerThreshold: 5,
+		BreakerTimeout:   30 * time.Second,
+	}
+}
+
+// IServiceResilient retries the failed calls of decoratorpkg.IService and stops them by the circuit breaker: IService service of the users
+type IServiceResilient struct {
+	base    decoratorpkg.IService
+	options IServiceResilientOptions
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// NewIServiceResilient returns the decorator of the base object.
+func NewIServiceResilient(base decoratorpkg.IService, options IServiceResilientOptions) *IServiceResilient {
+	return &IServiceResilient{base: base, options: options}
+}
+
+// call calls fn with the timeout of the method and retries the failed calls with the exponential backoff
+// until the attempts are exhausted, the context is done or the circuit breaker is open.
+func (d *IServiceResilient) call(ctx context.Context, method string, fn func(ctx context.Context) error) error {
+	timeout, ok := d.options.MethodTimeouts[method]
+	if !ok {
+		timeout = d.options.Timeout
+	}
+
+	var err error
+	backoff := d.options.Backoff
+	for attempt := 1; ; attempt++ {
+		probe, ok := d.allow()
+		if !ok {
+			if err != nil {
+				return err
+			}
+			return ErrIServiceResilientOpen
+		}
+
+		err = d.attempt(ctx, timeout, fn)
+		d.done(ctx, probe, err)
+
+		if err == nil || attempt >= d.options.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if d.options.MaxBackoff > 0 {
+			backoff = min(backoff, d.options.MaxBackoff)
+		}
+	}
+}
+
+func (d *IServiceResilient) attempt(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return fn(ctx)
+}
+
+// allow reports whether the call is allowed: the circuit breaker is closed or the call is the probe call
+// of the half-open circuit breaker, the open time of which is over.
+func (d *IServiceResilient) allow() (probe, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.options.BreakerThreshold == 0 || d.failures < d.options.BreakerThreshold {
+		return false, true
+	}
+
+	if d.probing || time.Now().Before(d.openUntil) {
+		return false, false
+	}
+
+	d.probing = true
+	return true, true
+}
+
+// done counts the consecutive failed attempts, the threshold opens the circuit breaker. The errors of the done
+// context of the call are caused by the caller, they do not change the state of the circuit breaker.
+// The probe call ends the half-open state: its success closes the circuit breaker, its failure opens it again.
+func (d *IServiceResilient) done(ctx context.Context, probe bool, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if probe {
+		d.probing = false
+	}
+
+	if err == nil {
+		d.failures = 0
+		return
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	d.failures++
+	if d.options.BreakerThreshold != 0 && d.failures >= d.options.BreakerThreshold {
+		d.openUntil = time.Now().Add(d.options.BreakerTimeout)
+	}
+}
+
+// Close implements decoratorpkg.IService.
+func (d *IServiceResilient) Close() {
+	d.base.Close()
+}
+
+// Find implements decoratorpkg.IService.
+func (d *IServiceResilient) Find(ctx context.Context, ids ...int) (r0 []decoratorpkg.User, r1 error) {
+	r1 = d.call(ctx, "Find", func(ctx context.Context) error {
+		r0, r1 = d.base.Find(ctx, ids...)
+		return r1
+	})
+
+	return r0, r1
+}
+
+// Login implements decoratorpkg.IService.
+func (d *IServiceResilient) Login(ctx context.Context, user string, password string) (token string, err error) {
+	err = d.call(ctx, "Login", func(ctx context.Context) error {
+		token, err = d.base.Login(ctx, user, password)
+		return err
+	})
+
+	return token, err
+}
+
+// Name implements decoratorpkg.IService.
+func (d *IServiceResilient) Name() (r0 string) {
+	return d.base.Name()
+}
+
+// Ping implements decoratorpkg.IService.
+func (d *IServiceResilient) Ping() (r0 error) {
+	return d.base.Ping()
+}
+`,
+		string(data),
+	)
+}
+
+func TestPrepareFileListInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		MaxAttempts      int
+		MethodTimeouts   map[string]string
+		BreakerThreshold int
+		WantErr          string
+	}{
+		"zero attempts": {
+			MaxAttempts: 0,
+			WantErr:     "max attempts must be positive: 0",
+		},
+		"negative breaker threshold": {
+			MaxAttempts:      1,
+			BreakerThreshold: -1,
+			WantErr:          "breaker threshold must not be negative: -1",
+		},
+		"invalid timeout": {
+			MaxAttempts:    1,
+			MethodTimeouts: map[string]string{"Login": "1 second"},
+			WantErr:        `parse timeout of method(Login): time: unknown unit " second" in duration "1 second"`,
+		},
+		"method without context": {
+			MaxAttempts:    1,
+			MethodTimeouts: map[string]string{"Login": "1s", "Ping": "1s", "Open": "1s"},
+			WantErr:        "methods with timeouts are not found or have no context and error: [Open Ping]",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := prepareFileList(commandArgs{
+				interfaceType:    "github.com/khevse/codegen/tests/decoratorpkg.IService",
+				targetDir:        "./",
+				maxAttempts:      tc.MaxAttempts,
+				methodTimeouts:   tc.MethodTimeouts,
+				breakerThreshold: tc.BreakerThreshold,
+			})
+			require.EqualError(t, err, tc.WantErr)
+		})
+	}
+}
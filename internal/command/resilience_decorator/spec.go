@@ -0,0 +1,167 @@
+package resilience_decorator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/khevse/codegen/internal/pkg/interfacepkg"
+	"github.com/samber/lo"
+)
+
+// reservedNames are the names used by the generated methods, the arguments with these names are renamed.
+var reservedNames = []string{"d", "context", "errors", "sync", "time"}
+
+// options are the default options of the generated decorators.
+type options struct {
+	MaxAttempts      int
+	Backoff          time.Duration
+	MaxBackoff       time.Duration
+	Timeout          time.Duration
+	MethodTimeouts   map[string]time.Duration
+	BreakerThreshold int
+	BreakerTimeout   time.Duration
+}
+
+func newOptions(args commandArgs) (options, error) {
+	if args.maxAttempts < 1 {
+		return options{}, fmt.Errorf("max attempts must be positive: %d", args.maxAttempts)
+	}
+	if args.breakerThreshold < 0 {
+		return options{}, fmt.Errorf("breaker threshold must not be negative: %d", args.breakerThreshold)
+	}
+
+	methodTimeouts := make(map[string]time.Duration, len(args.methodTimeouts))
+	for method, val := range args.methodTimeouts {
+		timeout, err := time.ParseDuration(val)
+		if err != nil {
+			return options{}, fmt.Errorf("parse timeout of method(%s): %w", method, err)
+		}
+		methodTimeouts[method] = timeout
+	}
+
+	return options{
+		MaxAttempts:      args.maxAttempts,
+		Backoff:          args.backoff,
+		MaxBackoff:       args.maxBackoff,
+		Timeout:          args.timeout,
+		MethodTimeouts:   methodTimeouts,
+		BreakerThreshold: args.breakerThreshold,
+		BreakerTimeout:   args.breakerTimeout,
+	}, nil
+}
+
+type methodTimeout struct {
+	Method string
+	// Timeout is the Go expression of the timeout: 2 * time.Second.
+	Timeout string
+}
+
+type methodSpec struct {
+	interfacepkg.Method
+	// Decorated is true if the method has the context parameter and the error result,
+	// the other methods are passed to the base object.
+	Decorated bool
+	// ContextType is the type of the context parameter in the method signature.
+	ContextType string
+}
+
+type decoratorSpec struct {
+	Name          string
+	InterfaceName string
+	TypeName      string
+	TypeParams    string
+	TypeArgs      string
+	Comment       string
+	Methods       []methodSpec
+
+	MaxAttempts      int
+	Backoff          string
+	MaxBackoff       string
+	Timeout          string
+	MethodTimeouts   []methodTimeout
+	BreakerThreshold int
+	BreakerTimeout   string
+}
+
+type newDecoratorSpecParams struct {
+	spec    interfacepkg.Spec
+	options options
+	// timeouts are the methods with the timeouts found in the interface
+	timeouts map[string]struct{}
+}
+
+func newDecoratorSpec(params newDecoratorSpecParams) decoratorSpec {
+	methods := lo.Map(params.spec.Methods, func(method interfacepkg.Method, _ int) methodSpec {
+		ctxParam, _ := lo.Find(method.Params, func(item interfacepkg.Field) bool {
+			return item.IsContext()
+		})
+
+		return methodSpec{
+			Method:      method,
+			Decorated:   method.ContextParam() != "" && method.ErrorResult() != "",
+			ContextType: ctxParam.TypeName,
+		}
+	})
+
+	methodTimeouts := make([]methodTimeout, 0)
+	for _, method := range methods {
+		timeout, ok := params.options.MethodTimeouts[method.Name]
+		if !ok || !method.Decorated {
+			continue
+		}
+
+		params.timeouts[method.Name] = struct{}{}
+		methodTimeouts = append(methodTimeouts, methodTimeout{
+			Method:  method.Name,
+			Timeout: durationExpr(timeout),
+		})
+	}
+	slices.SortFunc(methodTimeouts, func(i, j methodTimeout) int {
+		return strings.Compare(i.Method, j.Method)
+	})
+
+	return decoratorSpec{
+		Name:          params.spec.Name,
+		InterfaceName: params.spec.InterfaceName,
+		TypeName:      params.spec.TypeName,
+		TypeParams:    params.spec.TypeParams,
+		TypeArgs:      params.spec.TypeArgs,
+		Comment:       params.spec.Comment,
+		Methods:       methods,
+
+		MaxAttempts:      params.options.MaxAttempts,
+		Backoff:          durationExpr(params.options.Backoff),
+		MaxBackoff:       durationExpr(params.options.MaxBackoff),
+		Timeout:          durationExpr(params.options.Timeout),
+		MethodTimeouts:   methodTimeouts,
+		BreakerThreshold: params.options.BreakerThreshold,
+		BreakerTimeout:   durationExpr(params.options.BreakerTimeout),
+	}
+}
+
+// durationExpr returns the Go expression of the duration in the largest whole unit: 1500 * time.Millisecond.
+func durationExpr(val time.Duration) string {
+	if val == 0 {
+		return "0"
+	}
+
+	units := []struct {
+		Name  string
+		Value time.Duration
+	}{
+		{Name: "time.Hour", Value: time.Hour},
+		{Name: "time.Minute", Value: time.Minute},
+		{Name: "time.Second", Value: time.Second},
+		{Name: "time.Millisecond", Value: time.Millisecond},
+		{Name: "time.Microsecond", Value: time.Microsecond},
+	}
+	for _, unit := range units {
+		if val%unit.Value == 0 {
+			return fmt.Sprintf("%d * %s", val/unit.Value, unit.Name)
+		}
+	}
+
+	return fmt.Sprintf("%d * time.Nanosecond", val)
+}
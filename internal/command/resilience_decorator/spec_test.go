@@ -0,0 +1,31 @@
+package resilience_decorator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationExpr(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Value time.Duration
+		Want  string
+	}{
+		"zero":         {Value: 0, Want: "0"},
+		"hours":        {Value: 2 * time.Hour, Want: "2 * time.Hour"},
+		"minutes":      {Value: 90 * time.Minute, Want: "90 * time.Minute"},
+		"seconds":      {Value: 30 * time.Second, Want: "30 * time.Second"},
+		"milliseconds": {Value: 1500 * time.Millisecond, Want: "1500 * time.Millisecond"},
+		"microseconds": {Value: 5 * time.Microsecond, Want: "5 * time.Microsecond"},
+		"nanoseconds":  {Value: 1001, Want: "1001 * time.Nanosecond"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.Want, durationExpr(tc.Value))
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package telemetry_decorator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute(t *testing.T) {
+	args := commandArgs{
+		interfaceType: "github.com/khevse/codegen/tests/decoratorpkg.IService",
+		targetDir:     "./",
+		fileSuffix:    "_generated",
+		metrics:       backendPrometheus,
+		tracing:       backendOtel,
+	}
+	require.NoError(t, (&Command{args: args}).Execute())
+
+	const wantFile = "i_service_telemetry_generated.go"
+	defer func() {
+		require.NoError(t, os.Remove(wantFile))
+	}()
+
+	data, err := os.ReadFile(wantFile)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package telemetry_decorator
+
+import (
+	context "context"
+	errors "errors"
+	fmt "fmt"
+	decoratorpkg "github.com/khevse/codegen/tests/decoratorpkg"
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	codes "go.opentelemetry.io/otel/codes"
+	trace "go.opentelemetry.io/otel/trace"
+	time "time"
+)
+
+var _ decoratorpkg.IService = (*IServiceTelemetry)(nil)
+
+// IServiceTelemetry records the metrics and the traces of the calls of decoratorpkg.IService: IService service of the users
+type IServiceTelemetry struct {
+	base     decoratorpkg.IService
+	tracer   trace.Tracer
+	calls    *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewIServiceTelemetry returns the decorator recording the calls of the base object.
+// The metrics i_service_calls_total and i_service_call_duration_seconds are labelled by the method,
+// the metrics registered by the other decorator of the same interface are reused.
+func NewIServiceTelemetry(
+	base decoratorpkg.IService,
+	tracer trace.Tracer,
+	registerer prometheus.Registerer,
+) (*IServiceTelemetry, error) {
+	d := &IServiceTelemetry{
+		base:   base,
+		tracer: tracer,
+	}
+
+	d.calls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "i_service_calls_total",
+		Help: "Number of the calls of IService.",
+	}, []string{"method", "status"})
+	if err := registerer.Register(d.calls); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if !errors.As(err, &registered) {
+			return nil, fmt.Errorf("register calls counter: %w", err)
+		}
+		existing, ok := registered.ExistingCollector.(*prometheus.CounterVec)
+		if !ok {
+			return nil, fmt.Errorf("register calls counter: registered collector is %T", registered.ExistingCollector)
+		}
+		d.calls = existing
+	}
+
+	d.duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "i_service_call_duration_seconds",
+		Help:    "Duration of the calls of IService.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+	if err := registerer.Register(d.duration); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if !errors.As(err, &registered) {
+			return nil, fmt.Errorf("register duration histogram: %w", err)
+		}
+		existing, ok := registered.ExistingCollector.(*prometheus.HistogramVec)
+		if !ok {
+			return nil, fmt.Errorf("register duration histogram: registered collector is %T", registered.ExistingCollector)
+		}
+		d.duration = existing
+	}
+
+	return d, nil
+}
+
+// Close implements decoratorpkg.IService.
+func (d *IServiceTelemetry) Close() {
+	dStart := time.Now()
+	_, dSpan := d.tracer.Start(context.Background(), "IService.Close")
+	defer dSpan.End()
+
+	d.base.Close()
+
+	dStatus := "ok"
+
+	d.calls.WithLabelValues("Close", dStatus).Inc()
+	d.duration.WithLabelValues("Close").Observe(time.Since(dStart).Seconds())
+}
+
+// Find implements decoratorpkg.IService.
+func (d *IServiceTelemetry) Find(ctx context.Context, ids ...int) (r0 []decoratorpkg.User, r1 error) {
+	dStart := time.Now()
+	ctx, dSpan := d.tracer.Start(ctx, "IService.Find")
+	defer dSpan.End()
+
+	r0, r1 = d.base.Find(ctx, ids...)
+
+	dStatus := "ok"
+	if r1 != nil {
+		dStatus = "error"
+		dSpan.RecordError(r1)
+		dSpan.SetStatus(codes.Error, r1.Error())
+	}
+
+	d.calls.WithLabelValues("Find", dStatus).Inc()
+	d.duration.WithLabelValues("Find").Observe(time.Since(dStart).Seconds())
+
+	return r0, r1
+}
+
+// Login implements decoratorpkg.IService.
+func (d *IServiceTelemetry) Login(ctx context.Context, user string, password string) (token string, err error) {
+	dStart := time.Now()
+	ctx, dSpan := d.tracer.Start(ctx, "IService.Login")
+	defer dSpan.End()
+
+	token, err = d.base.Login(ctx, user, password)
+
+	dStatus := "ok"
+	if err != nil {
+		dStatus = "error"
+		dSpan.RecordError(err)
+		dSpan.SetStatus(codes.Error, err.Error())
+	}
+
+	d.calls.WithLabelValues("Login", dStatus).Inc()
+	d.duration.WithLabelValues("Login").Observe(time.Since(dStart).Seconds())
+
+	return token, err
+}
+
+// Name implements decoratorpkg.IService.
+func (d *IServiceTelemetry) Name() (r0 string) {
+	dStart := time.Now()
+	_, dSpan := d.tracer.Start(context.Background(), "IService.Name")
+	defer dSpan.End()
+
+	r0 = d.base.Name()
+
+	dStatus := "ok"
+
+	d.calls.WithLabelValues("Name", dStatus).Inc()
+	d.duration.WithLabelValues("Name").Observe(time.Since(dStart).Seconds())
+
+	return r0
+}
+
+// Ping implements decoratorpkg.IService.
+func (d *IServiceTelemetry) Ping() (r0 error) {
+	dStart := time.Now()
+	_, dSpan := d.tracer.Start(context.Background(), "IService.Ping")
+	defer dSpan.End()
+
+	r0 = d.base.Ping()
+
+	dStatus := "ok"
+	if r0 != nil {
+		dStatus = "error"
+		dSpan.RecordError(r0)
+		dSpan.SetStatus(codes.Error, r0.Error())
+	}
+
+	d.calls.WithLabelValues("Ping", dStatus).Inc()
+	d.duration.WithLabelValues("Ping").Observe(time.Since(dStart).Seconds())
+
+	return r0
+}
+`,
+		string(data),
+	)
+}
+
+func TestPrepareFileList(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		InterfaceType string
+		Metrics       string
+		Tracing       string
+		WantImports   []string
+	}{
+		"prometheus metrics": {
+			InterfaceType: "github.com/khevse/codegen/tests/decoratorpkg.IService",
+			Metrics:       backendPrometheus,
+			Tracing:       backendNone,
+			WantImports: []string{
+				// the context is used by the methods of the interface
+				"context",
+				"errors",
+				"fmt",
+				"github.com/khevse/codegen/tests/decoratorpkg",
+				"github.com/prometheus/client_golang/prometheus",
+				"time",
+			},
+		},
+		"otel metrics and tracing": {
+			InterfaceType: "github.com/khevse/codegen/tests/decoratorpkg.IService",
+			Metrics:       backendOtel,
+			Tracing:       backendOtel,
+			WantImports: []string{
+				"context",
+				"fmt",
+				"github.com/khevse/codegen/tests/decoratorpkg",
+				"go.opentelemetry.io/otel/attribute",
+				"go.opentelemetry.io/otel/codes",
+				"go.opentelemetry.io/otel/metric",
+				"go.opentelemetry.io/otel/trace",
+				"time",
+			},
+		},
+		"tracing of the methods without errors": {
+			InterfaceType: "github.com/khevse/codegen/tests/mainpkg.IObject1",
+			Metrics:       backendNone,
+			Tracing:       backendOtel,
+			WantImports: []string{
+				"context",
+				"github.com/khevse/codegen/tests/mainpkg",
+				"go.opentelemetry.io/otel/trace",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			list, err := prepareFileList(commandArgs{
+				interfaceType: tc.InterfaceType,
+				targetDir:     "./",
+				metrics:       tc.Metrics,
+				tracing:       tc.Tracing,
+			})
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			require.Equal(t, backends{Metrics: tc.Metrics, Tracing: tc.Tracing}, list[0].Backends)
+
+			imports := lo.Map(list[0].Imports, func(item astpkg.Import, _ int) string { return item.Path })
+			require.Empty(t, cmp.Diff(tc.WantImports, imports, cmpopts.SortSlices(func(i, j string) bool { return i < j })))
+		})
+	}
+}
+
+func TestPrepareFileListInvalidBackends(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Metrics string
+		Tracing string
+		WantErr string
+	}{
+		"unknown metrics": {
+			Metrics: "statsd",
+			Tracing: backendOtel,
+			WantErr: `unknown metrics backend "statsd", supported: [prometheus otel none]`,
+		},
+		"unknown tracing": {
+			Metrics: backendPrometheus,
+			Tracing: "zipkin",
+			WantErr: `unknown tracing backend "zipkin", supported: [otel none]`,
+		},
+		"all disabled": {
+			Metrics: backendNone,
+			Tracing: backendNone,
+			WantErr: "metrics and tracing are disabled",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := prepareFileList(commandArgs{
+				interfaceType: "github.com/khevse/codegen/tests/decoratorpkg.IService",
+				targetDir:     "./",
+				metrics:       tc.Metrics,
+				tracing:       tc.Tracing,
+			})
+			require.EqualError(t, err, tc.WantErr)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package telemetry_decorator
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/khevse/codegen/internal/pkg/interfacepkg"
+	"github.com/khevse/codegen/internal/pkg/stringspkg"
+	"github.com/samber/lo"
+)
+
+const (
+	backendNone       = "none"
+	backendPrometheus = "prometheus"
+	backendOtel       = "otel"
+)
+
+var (
+	metricsBackends = []string{backendPrometheus, backendOtel, backendNone}
+	tracingBackends = []string{backendOtel, backendNone}
+)
+
+// reservedNames are the names used by the generated methods, the arguments with these names are renamed.
+var reservedNames = []string{
+	"d", "dStart", "dSpan", "dStatus",
+	"attribute", "codes", "context", "errors", "fmt", "metric", "prometheus", "time", "trace",
+}
+
+// backends are the metrics and the tracing backends of the decorators.
+type backends struct {
+	Metrics string
+	Tracing string
+}
+
+func newBackends(metrics, tracing string) (backends, error) {
+	if !slices.Contains(metricsBackends, metrics) {
+		return backends{}, fmt.Errorf("unknown metrics backend %q, supported: %v", metrics, metricsBackends)
+	}
+	if !slices.Contains(tracingBackends, tracing) {
+		return backends{}, fmt.Errorf("unknown tracing backend %q, supported: %v", tracing, tracingBackends)
+	}
+	if metrics == backendNone && tracing == backendNone {
+		return backends{}, fmt.Errorf("metrics and tracing are disabled")
+	}
+
+	return backends{Metrics: metrics, Tracing: tracing}, nil
+}
+
+// imports returns the packages used by the generated code of the decorator.
+func (b backends) imports(spec decoratorSpec) []string {
+	res := make([]string, 0)
+
+	switch b.Metrics {
+	case backendPrometheus:
+		res = append(res, "errors", "fmt", "time", "github.com/prometheus/client_golang/prometheus")
+	case backendOtel:
+		res = append(res, "fmt", "time", "go.opentelemetry.io/otel/attribute", "go.opentelemetry.io/otel/metric")
+	}
+
+	if b.Tracing == backendOtel {
+		res = append(res, "go.opentelemetry.io/otel/trace")
+		if spec.HasErrors() {
+			res = append(res, "go.opentelemetry.io/otel/codes")
+		}
+	}
+
+	// the span and the otel metrics require the context
+	if spec.NeedsBackground() && (b.Tracing == backendOtel || b.Metrics == backendOtel) {
+		res = append(res, "context")
+	}
+
+	return lo.Uniq(res)
+}
+
+// allImports returns the packages used by the generated code of all backends.
+func allImports() []string {
+	return []string{
+		"context",
+		"errors",
+		"fmt",
+		"time",
+		"github.com/prometheus/client_golang/prometheus",
+		"go.opentelemetry.io/otel/attribute",
+		"go.opentelemetry.io/otel/codes",
+		"go.opentelemetry.io/otel/metric",
+		"go.opentelemetry.io/otel/trace",
+	}
+}
+
+type decoratorSpec struct {
+	Name          string
+	InterfaceName string
+	TypeName      string
+	TypeParams    string
+	TypeArgs      string
+	Comment       string
+	// MetricPrefix is the prefix of the metric names: i_service.
+	MetricPrefix string
+	Methods      []interfacepkg.Method
+}
+
+// HasErrors returns true if at least one method returns error.
+func (s decoratorSpec) HasErrors() bool {
+	return lo.SomeBy(s.Methods, func(item interfacepkg.Method) bool {
+		return item.ErrorResult() != ""
+	})
+}
+
+// NeedsBackground returns true if at least one method has no context parameter.
+func (s decoratorSpec) NeedsBackground() bool {
+	return lo.SomeBy(s.Methods, func(item interfacepkg.Method) bool {
+		return item.ContextParam() == ""
+	})
+}
+
+func newDecoratorSpec(spec interfacepkg.Spec) decoratorSpec {
+	return decoratorSpec{
+		Name:          spec.Name,
+		InterfaceName: spec.InterfaceName,
+		TypeName:      spec.TypeName,
+		TypeParams:    spec.TypeParams,
+		TypeArgs:      spec.TypeArgs,
+		Comment:       spec.Comment,
+		MetricPrefix:  stringspkg.ToSnakeCase(spec.TypeName),
+		Methods:       spec.Methods,
+	}
+}
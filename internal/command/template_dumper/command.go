@@ -0,0 +1,83 @@
+package template_dumper
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/khevse/codegen/internal/pkg/command"
+)
+
+type commandArgs struct {
+	commandName string
+}
+
+// templateGetter is implemented by the commands generating the files by the template.
+type templateGetter interface {
+	DefaultTemplate() ([]byte, error)
+}
+
+type Command struct {
+	args     commandArgs
+	commands []command.Command
+	output   io.Writer
+}
+
+// New returns the command writing the default template of the commands to stdout.
+func New(commands ...command.Command) *Command {
+	return &Command{
+		commands: commands,
+		output:   os.Stdout,
+	}
+}
+
+func (c *Command) Name() string {
+	return "template"
+}
+
+func (c *Command) ShortName() string {
+	return "tmpl"
+}
+
+func (c *Command) InitFlags(flagSetter command.FlagSetter) error {
+	const (
+		flagCommandName = "command"
+	)
+
+	flagSetter.Flags().StringVarP(
+		&c.args.commandName,
+		flagCommandName,
+		"c",
+		"",
+		"command name of the template",
+	)
+
+	if err := flagSetter.MarkFlagRequired(flagCommandName); err != nil {
+		return fmt.Errorf("mark flag as required(%s): %w", flagCommandName, err)
+	}
+
+	return nil
+}
+
+func (c *Command) Execute() error {
+	cmd, ok := command.Find(c.commands, c.args.commandName)
+	if !ok {
+		return fmt.Errorf("unknown command: %s", c.args.commandName)
+	}
+
+	getter, ok := cmd.(templateGetter)
+	if !ok {
+		return fmt.Errorf("command does not use template: %s", cmd.Name())
+	}
+
+	data, err := getter.DefaultTemplate()
+	if err != nil {
+		return fmt.Errorf("get template(%s): %w", cmd.Name(), err)
+	}
+
+	if _, err := c.output.Write(data); err != nil {
+		return fmt.Errorf("write template: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package template_dumper
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/khevse/codegen/internal/command/config_runner"
+	"github.com/khevse/codegen/internal/command/interface_creator"
+	"github.com/khevse/codegen/internal/command/mock_creator"
+	"github.com/khevse/codegen/internal/command/object_test_wrapper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	newCommand := func(commandName string) (*Command, *bytes.Buffer) {
+		output := bytes.NewBuffer(nil)
+
+		c := New(
+			interface_creator.New(),
+			object_test_wrapper.New(),
+			mock_creator.New(),
+			config_runner.New(),
+		)
+		c.args.commandName = commandName
+		c.output = output
+
+		return c, output
+	}
+
+	for _, commandName := range []string{"interface", "i", "object-test-wrapper", "w", "mock", "m"} {
+		t.Run(commandName, func(t *testing.T) {
+			c, output := newCommand(commandName)
+			require.NoError(t, c.Execute())
+			require.True(t, strings.HasPrefix(output.String(), "// Code generated by http://github.com/khevse/codegen"))
+		})
+	}
+
+	t.Run("unknown command", func(t *testing.T) {
+		c, _ := newCommand("unknown")
+		require.EqualError(t, c.Execute(), "unknown command: unknown")
+	})
+
+	t.Run("command without template", func(t *testing.T) {
+		c, _ := newCommand("run")
+		require.EqualError(t, c.Execute(), "command does not use template: run")
+	})
+}
@@ -0,0 +1,162 @@
+package astpkg
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// directivePrefix is the prefix of the codegen directives in the doc comments.
+const directivePrefix = "//codegen:"
+
+// Directive is the codegen directive from the doc comment: //codegen:interface name=IStore dir=./store.
+// The arguments without the value have the empty value.
+type Directive struct {
+	Name string
+	Args map[string]string
+}
+
+// Arg returns the value of the argument or the default value if the argument is not set.
+func (d Directive) Arg(name, defaultValue string) string {
+	if val, ok := d.Args[name]; ok {
+		return val
+	}
+
+	return defaultValue
+}
+
+type DirectiveList []Directive
+
+func (l DirectiveList) GetByName(name string) (Directive, bool) {
+	return lo.Find(l, func(item Directive) bool {
+		return item.Name == name
+	})
+}
+
+// newDirectiveList returns the codegen directives of the comment, the invalid arguments are ignored.
+func newDirectiveList(doc *ast.CommentGroup) DirectiveList {
+	if doc == nil {
+		return nil
+	}
+
+	var list DirectiveList
+	for _, comment := range doc.List {
+		if directive, ok := parseDirective(comment.Text); ok {
+			list = append(list, directive)
+		}
+	}
+
+	return list
+}
+
+// parseDirective parses the codegen directive, the quoted values may contain spaces: name="a b".
+func parseDirective(text string) (Directive, bool) {
+	rest, ok := strings.CutPrefix(text, directivePrefix)
+	if !ok {
+		return Directive{}, false
+	}
+
+	name, rest, _ := strings.Cut(rest, " ")
+	if name == "" {
+		return Directive{}, false
+	}
+
+	directive := Directive{
+		Name: name,
+		Args: make(map[string]string),
+	}
+
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+		end := strings.IndexAny(rest, " =")
+		if end == -1 || rest[end] == ' ' {
+			end = lo.Ternary(end == -1, len(rest), end)
+			directive.Args[rest[:end]] = ""
+			rest = rest[end:]
+			continue
+		}
+
+		key := rest[:end]
+		rest = rest[end+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return Directive{}, false
+			}
+
+			value, _ = strconv.Unquote(quoted)
+			rest = rest[len(quoted):]
+		} else {
+			value, rest, _ = strings.Cut(rest, " ")
+		}
+
+		directive.Args[key] = value
+	}
+
+	return directive, true
+}
+
+// AnnotatedType is the type declaration with the codegen directive.
+type AnnotatedType struct {
+	Package   *Package
+	TypeDecl  *TypeDecl
+	Directive Directive
+}
+
+// FindAnnotatedTypes returns the types with the directive from the packages matched by the patterns (./...).
+// Only the packages containing the directive are parsed.
+func FindAnnotatedTypes(cache *PackageCache, name string, patterns ...string) ([]AnnotatedType, error) {
+	pkgFilesList, err := ListPackageFiles(patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]AnnotatedType, 0)
+	for _, pkgFiles := range pkgFilesList {
+		found, err := containsDirective(pkgFiles.GoFiles, directivePrefix+name)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		pkg, err := cache.ParsePackage(pkgFiles.Path)
+		if err != nil {
+			return nil, fmt.Errorf("parse package(%s): %w", pkgFiles.Path, err)
+		}
+
+		for _, typeDecl := range pkg.TypeDeclList {
+			if directive, ok := typeDecl.Directives.GetByName(name); ok {
+				res = append(res, AnnotatedType{
+					Package:   pkg,
+					TypeDecl:  typeDecl,
+					Directive: directive,
+				})
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func containsDirective(files []string, directive string) (bool, error) {
+	for _, filePath := range files {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return false, fmt.Errorf("read file(%s): %w", filePath, err)
+		}
+
+		if bytes.Contains(data, []byte(directive)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,95 @@
+package astpkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDirective(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Text   string
+		Want   Directive
+		WantOK bool
+	}{
+		"without args": {
+			Text:   "//codegen:interface",
+			Want:   Directive{Name: "interface", Args: map[string]string{}},
+			WantOK: true,
+		},
+		"args": {
+			Text: `//codegen:wrapper  name=Wrapper mock=./mocks ignore comment="a b\"c"`,
+			Want: Directive{
+				Name: "wrapper",
+				Args: map[string]string{"name": "Wrapper", "mock": "./mocks", "ignore": "", "comment": `a b"c`},
+			},
+			WantOK: true,
+		},
+		"empty value": {
+			Text:   "//codegen:interface name=",
+			Want:   Directive{Name: "interface", Args: map[string]string{"name": ""}},
+			WantOK: true,
+		},
+		"invalid quoted value": {
+			Text: `//codegen:interface name="IStore`,
+		},
+		"another directive": {
+			Text: "//go:generate codegen interface",
+		},
+		"without name": {
+			Text: "//codegen: name=IStore",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res, ok := parseDirective(tc.Text)
+			require.Equal(t, tc.WantOK, ok)
+			require.Equal(t, tc.Want, res)
+		})
+	}
+}
+
+func TestFindAnnotatedTypes(t *testing.T) {
+	t.Parallel()
+
+	res, err := FindAnnotatedTypes(nil, "interface", "github.com/khevse/codegen/tests/...")
+	require.NoError(t, err)
+
+	type annotatedType struct {
+		Package   string
+		Name      string
+		Comment   string
+		Directive Directive
+	}
+
+	list := make([]annotatedType, 0, len(res))
+	for _, item := range res {
+		list = append(list, annotatedType{
+			Package:   item.Package.Path,
+			Name:      item.TypeDecl.Name,
+			Comment:   item.TypeDecl.Comment,
+			Directive: item.Directive,
+		})
+	}
+
+	require.Equal(t,
+		[]annotatedType{
+			{
+				Package:   "github.com/khevse/codegen/tests/annotatedpkg",
+				Name:      "Store",
+				Comment:   "Store storage of the values",
+				Directive: Directive{Name: "interface", Args: map[string]string{"name": "IStore"}},
+			},
+			{
+				Package:   "github.com/khevse/codegen/tests/annotatedpkg",
+				Name:      "Counter",
+				Comment:   "Counter counter of the values",
+				Directive: Directive{Name: "interface", Args: map[string]string{}},
+			},
+		},
+		list,
+	)
+}
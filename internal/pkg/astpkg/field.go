@@ -3,6 +3,7 @@ package astpkg
 import (
 	"fmt"
 	"go/ast"
+	"strings"
 
 	"github.com/samber/lo"
 )
@@ -10,25 +11,48 @@ import (
 type Field struct {
 	Name string
 	Type Type
+	// Directives are the codegen directives of the doc comment, they are set for the interface methods.
+	Directives DirectiveList
 }
 
 func NewFieldList(fieldList *ast.FieldList) []*Field {
+	return newFieldList(fieldList, nil)
+}
+
+func newFieldList(fieldList *ast.FieldList, resolver *typeResolver) []*Field {
 	list := make([]*Field, 0, fieldList.NumFields())
 	if fieldList != nil {
 		for _, field := range fieldList.List {
-			list = append(list, NewField(field)...)
+			list = append(list, newField(field, resolver)...)
 		}
 	}
 
 	return list
 }
 
+func NewTypeParamList(fieldList *ast.FieldList) []*Field {
+	return newTypeParamList(fieldList, nil)
+}
+
+func newTypeParamList(fieldList *ast.FieldList, resolver *typeResolver) []*Field {
+	if fieldList == nil || len(fieldList.List) == 0 {
+		return nil
+	}
+
+	return newFieldList(fieldList, resolver)
+}
+
 func NewField(field *ast.Field) []*Field {
+	return newField(field, nil)
+}
+
+func newField(field *ast.Field, resolver *typeResolver) []*Field {
 	if len(field.Names) == 0 {
 		return []*Field{
 			{
-				Name: "",
-				Type: NewType(field.Type),
+				Name:       "",
+				Type:       newType(field.Type, resolver),
+				Directives: newDirectiveList(field.Doc),
 			},
 		}
 	}
@@ -38,8 +62,9 @@ func NewField(field *ast.Field) []*Field {
 		list = append(
 			list,
 			&Field{
-				Name: nameIdent.Name,
-				Type: NewType(field.Type),
+				Name:       nameIdent.Name,
+				Type:       newType(field.Type, resolver),
+				Directives: newDirectiveList(field.Doc),
 			},
 		)
 	}
@@ -61,3 +86,62 @@ func InspectFields(fieldList []*Field, fn func(*Field) error) error {
 
 	return nil
 }
+
+// TypeParamsDecl returns type parameters in the declaration form: [K comparable, V any].
+func TypeParamsDecl(typeParams []*Field) string {
+	if len(typeParams) == 0 {
+		return ""
+	}
+
+	list := lo.Map(typeParams, func(item *Field, _ int) string {
+		return fmt.Sprintf("%s %s", item.Name, item.Type.ExprString())
+	})
+
+	return fmt.Sprintf("[%s]", strings.Join(list, ", "))
+}
+
+// TypeParamsArgs returns type parameters in the instantiation form: [K, V].
+func TypeParamsArgs(typeParams []*Field) string {
+	if len(typeParams) == 0 {
+		return ""
+	}
+
+	list := lo.Map(typeParams, func(item *Field, _ int) string { return item.Name })
+
+	return fmt.Sprintf("[%s]", strings.Join(list, ", "))
+}
+
+// TypeParamsDeclWithImports returns type parameters in the declaration form with the package aliases
+// from the import list: the import with the empty alias makes the declarations of its package unqualified.
+// The types of the type parameters are not changed.
+func TypeParamsDeclWithImports(typeParams []*Field, imports ImportList) (string, error) {
+	var carriers []PackageCarrierType
+	err := inspectFieldsTypes(typeParams, func(t Type) error {
+		if casted, ok := t.(PackageCarrierType); ok && !isBaseType(t) {
+			carriers = append(carriers, casted)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	prevImports := lo.Map(carriers, func(item PackageCarrierType, _ int) Import {
+		return NewImport(item.GetPackage(), item.GetPackagePath())
+	})
+	defer func() {
+		for i, item := range carriers {
+			item.SetPackage(prevImports[i])
+		}
+	}()
+
+	for _, item := range carriers {
+		imp, ok := imports.GetByPath(item.GetPackagePath())
+		if !ok {
+			return "", fmt.Errorf("get import by path: %s", item.GetPackagePath())
+		}
+		item.SetPackage(imp)
+	}
+
+	return TypeParamsDecl(typeParams), nil
+}
@@ -26,3 +26,25 @@ func TestNewFieldList(t *testing.T) {
 		resultsFields,
 	)
 }
+
+func TestTypeParamsDeclWithImports(t *testing.T) {
+	t.Parallel()
+
+	typeParams := []*Field{
+		{Name: "K", Type: &SelectorExpr{Package: "cmp", PackagePath: "cmp", Name: "Ordered"}},
+		{Name: "V", Type: &Ident{Package: "mainpkg", PackagePath: "example.com/mainpkg", Name: "Number"}},
+		{Name: "S", Type: &TildeExpr{Type: &ArrayType{Type: &Ident{Name: "V", TypeParam: true}}}},
+	}
+
+	got, err := TypeParamsDeclWithImports(typeParams, ImportList{
+		{Alias: "cmp2", Path: "cmp"},
+		{Alias: "", Path: "example.com/mainpkg"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "[K cmp2.Ordered, V Number, S ~[]V]", got)
+	require.Equal(t, "[K cmp.Ordered, V mainpkg.Number, S ~[]V]", TypeParamsDecl(typeParams))
+
+	_, err = TypeParamsDeclWithImports(typeParams, ImportList{{Alias: "cmp", Path: "cmp"}})
+	require.EqualError(t, err, "get import by path: example.com/mainpkg")
+	require.Equal(t, "[K cmp.Ordered, V mainpkg.Number, S ~[]V]", TypeParamsDecl(typeParams))
+}
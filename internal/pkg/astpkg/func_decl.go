@@ -9,11 +9,20 @@ import (
 )
 
 type FuncDecl struct {
-	Receiver string
-	Name     string
-	Comment  string
-	Params   []*Field
-	Results  []*Field
+	Receiver           string
+	ReceiverTypeParams []string
+	// PointerReceiver is true for the methods of the pointer receiver: func (s *T) Method().
+	// The promoted methods have the pointer receiver if they are not in the method set of the value.
+	PointerReceiver bool
+	Name            string
+	Comment         string
+	// Directives are the codegen directives of the doc comment, they are not included to the Comment.
+	Directives DirectiveList
+	TypeParams []*Field
+	Params     []*Field
+	Results    []*Field
+	// PromotedFrom is the selector of the embedded field for the promoted methods.
+	PromotedFrom string
 }
 
 func (t FuncDecl) String() string {
@@ -26,6 +35,9 @@ func (t FuncDecl) String() string {
 
 func (t FuncDecl) GetSignatureImports() ImportList {
 	imports := make(ImportList, 0)
+	for _, field := range t.TypeParams {
+		imports = append(imports, field.Type.Imports()...)
+	}
 	for _, field := range t.Params {
 		imports = append(imports, field.Type.Imports()...)
 	}
@@ -44,36 +56,87 @@ func (l FuncDeclList) GetByReceiverName(name string) FuncDeclList {
 }
 
 func NewFuncDecl(spec *ast.FuncDecl) *FuncDecl {
+	return newFuncDecl(spec, nil)
+}
+
+func newFuncDecl(spec *ast.FuncDecl, resolver *typeResolver) *FuncDecl {
 	var specComment string
 	if doc := spec.Doc; doc != nil {
 		specComment = strings.TrimSpace(doc.Text())
 	}
 
-	params := NewFieldList(spec.Type.Params)
-	results := NewFieldList(spec.Type.Results)
+	typeParams := newTypeParamList(spec.Type.TypeParams, resolver)
+	params := newFieldList(spec.Type.Params, resolver)
+	results := newFieldList(spec.Type.Results, resolver)
 
-	var recvName string
+	var (
+		recvName       string
+		recvTypeParams []string
+		recvPointer    bool
+	)
 	if spec.Recv != nil && len(spec.Recv.List) == 1 {
-		switch t := spec.Recv.List[0].Type.(type) {
-		case *ast.Ident:
-			recvName = t.Name
-		case *ast.StarExpr:
-			if id, ok := t.X.(*ast.Ident); ok {
-				recvName = id.Name
-			}
-		}
+		recvName, recvTypeParams = parseReceiverType(spec.Recv.List[0].Type)
+		recvPointer = isPointerReceiver(spec.Recv.List[0].Type)
 	}
 
 	return &FuncDecl{
-		Receiver: recvName,
-		Name:     spec.Name.Name,
-		Comment:  specComment,
-		Params:   params,
-		Results:  results,
+		Receiver:           recvName,
+		ReceiverTypeParams: recvTypeParams,
+		PointerReceiver:    recvPointer,
+		Name:               spec.Name.Name,
+		Comment:            specComment,
+		Directives:         newDirectiveList(spec.Doc),
+		TypeParams:         typeParams,
+		Params:             params,
+		Results:            results,
+	}
+}
+
+func parseReceiverType(expr ast.Expr) (string, []string) {
+	identNames := func(list ...ast.Expr) []string {
+		return lo.FilterMap(list, func(item ast.Expr, _ int) (string, bool) {
+			id, ok := item.(*ast.Ident)
+			if !ok {
+				return "", false
+			}
+			return id.Name, true
+		})
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name, nil
+	case *ast.StarExpr:
+		return parseReceiverType(t.X)
+	case *ast.IndexExpr:
+		name, _ := parseReceiverType(t.X)
+		return name, identNames(t.Index)
+	case *ast.IndexListExpr:
+		name, _ := parseReceiverType(t.X)
+		return name, identNames(t.Indices...)
+	case *ast.ParenExpr:
+		return parseReceiverType(t.X)
+	default:
+		return "", nil
+	}
+}
+
+func isPointerReceiver(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return true
+	case *ast.ParenExpr:
+		return isPointerReceiver(t.X)
+	default:
+		return false
 	}
 }
 
 func InspectFuncDeclFields(funcDecl *FuncDecl, fn func(*Field) error) error {
+	if err := InspectFields(funcDecl.TypeParams, fn); err != nil {
+		return fmt.Errorf("inspect type params: %w", err)
+	}
+
 	if err := InspectFields(funcDecl.Params, fn); err != nil {
 		return fmt.Errorf("inspect params: %w", err)
 	}
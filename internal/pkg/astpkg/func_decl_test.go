@@ -56,6 +56,29 @@ func TestNewFuncDecl(t *testing.T) {
 		)
 	})
 
+	t.Run("function with directive", func(t *testing.T) {
+		funcDecl := newFuncDeclForTest(
+			t,
+			`package p;
+
+			// test comment
+			//codegen:ignore
+			func test() {};`,
+		)
+		require.Equal(
+			t,
+			&FuncDecl{
+				Receiver:   "",
+				Name:       "test",
+				Comment:    "test comment",
+				Directives: DirectiveList{{Name: "ignore", Args: map[string]string{}}},
+				Params:     []*Field{},
+				Results:    []*Field{},
+			},
+			funcDecl,
+		)
+	})
+
 	t.Run("method of receiver", func(t *testing.T) {
 		funcDecl := newFuncDeclForTest(
 			t,
@@ -67,11 +90,12 @@ func TestNewFuncDecl(t *testing.T) {
 		require.Equal(
 			t,
 			&FuncDecl{
-				Receiver: "Struct",
-				Name:     "test",
-				Comment:  "test comment",
-				Params:   []*Field{},
-				Results:  []*Field{},
+				Receiver:        "Struct",
+				PointerReceiver: true,
+				Name:            "test",
+				Comment:         "test comment",
+				Params:          []*Field{},
+				Results:         []*Field{},
 			},
 			funcDecl,
 		)
@@ -97,6 +121,53 @@ func TestNewFuncDecl(t *testing.T) {
 			funcDecl,
 		)
 	})
+	t.Run("method of generic receiver", func(t *testing.T) {
+		funcDecl := newFuncDeclForTest(
+			t,
+			`package p;
+
+			func (c *Cache[K, _]) test(key K) {};`,
+		)
+		require.Equal(
+			t,
+			&FuncDecl{
+				Receiver:           "Cache",
+				ReceiverTypeParams: []string{"K", "_"},
+				PointerReceiver:    true,
+				Name:               "test",
+				Comment:            "",
+				Params:             []*Field{{Name: "key", Type: &Ident{Name: "K"}}},
+				Results:            []*Field{},
+			},
+			funcDecl,
+		)
+	})
+
+	t.Run("generic function", func(t *testing.T) {
+		funcDecl := newFuncDeclForTest(
+			t,
+			`package p;
+
+			func test[T any, S ~[]T](val S) T {};`,
+		)
+		require.Equal(
+			t,
+			&FuncDecl{
+				Receiver: "",
+				Name:     "test",
+				Comment:  "",
+				TypeParams: []*Field{
+					{Name: "T", Type: &Ident{Name: "any"}},
+					{Name: "S", Type: &TildeExpr{Type: &ArrayType{Type: &Ident{Name: "T"}}}},
+				},
+				Params:  []*Field{{Name: "val", Type: &Ident{Name: "S"}}},
+				Results: []*Field{{Name: "", Type: &Ident{Name: "T"}}},
+			},
+			funcDecl,
+		)
+		require.Equal(t, "[T any, S ~[]T]", TypeParamsDecl(funcDecl.TypeParams))
+		require.Equal(t, "[T, S]", TypeParamsArgs(funcDecl.TypeParams))
+	})
 }
 
 func TestGetFuncDeclAllImportPath(t *testing.T) {
@@ -0,0 +1,173 @@
+package astpkg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// ErrMethodConflict is returned when the interface contains methods
+// with the same name and different signatures.
+var ErrMethodConflict = errors.New("method conflict")
+
+type methodSetItem struct {
+	method *Field
+	source string
+}
+
+// GetInterfaceMethods returns the method set of the interface: the explicit methods and
+// the methods of the embedded interfaces in the declaration order. The methods with
+// the same name and identical signatures are added once, the methods with the same
+// name and different signatures are reported by ErrMethodConflict.
+//
+// The type set terms of the constraint interfaces (unions, ~T, non-interface types)
+// have no methods and are skipped.
+func GetInterfaceMethods(t *InterfaceType) ([]*Field, error) {
+	list := make([]methodSetItem, 0, len(t.Methods))
+	if err := collectInterfaceMethods(t, "", &list); err != nil {
+		return nil, err
+	}
+
+	return lo.Map(list, func(item methodSetItem, _ int) *Field {
+		return item.method
+	}), nil
+}
+
+func collectInterfaceMethods(t *InterfaceType, source string, list *[]methodSetItem) error {
+	for _, item := range t.Methods {
+		if item.Name != "" {
+			if err := addInterfaceMethod(list, methodSetItem{method: item, source: source}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		embeddedSource := item.Type.ExprString()
+		if source != "" {
+			embeddedSource = source + "." + embeddedSource
+		}
+
+		switch casted := GetUnderlyingType(item.Type).(type) {
+		case *InterfaceType:
+			if err := collectInterfaceMethods(casted, embeddedSource, list); err != nil {
+				return err
+			}
+		case nil:
+			if ident, ok := item.Type.(*Ident); ok && isBaseType(ident) {
+				if ident.Name == "error" {
+					if err := addInterfaceMethod(list, methodSetItem{method: newErrorMethod(), source: embeddedSource}); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			return fmt.Errorf("embedded type %s is not resolved", embeddedSource)
+		}
+	}
+
+	return nil
+}
+
+func addInterfaceMethod(list *[]methodSetItem, item methodSetItem) error {
+	exists, ok := lo.Find(*list, func(existsItem methodSetItem) bool {
+		return existsItem.method.Name == item.method.Name
+	})
+	if !ok {
+		*list = append(*list, item)
+		return nil
+	}
+
+	if typeIdentity(exists.method.Type) == typeIdentity(item.method.Type) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: %s %s%s and %s%s",
+		ErrMethodConflict,
+		item.method.Name,
+		methodSourceString(exists.source),
+		exists.method.Type.ExprString(),
+		methodSourceString(item.source),
+		item.method.Type.ExprString(),
+	)
+}
+
+func methodSourceString(source string) string {
+	if source == "" {
+		return ""
+	}
+	return fmt.Sprintf("(from %s) ", source)
+}
+
+// newErrorMethod returns the method of the predeclared error interface.
+func newErrorMethod() *Field {
+	return &Field{
+		Name: "Error",
+		Type: &FuncType{
+			Params:  []*Field{},
+			Results: []*Field{{Name: "", Type: &Ident{Name: "string"}}},
+		},
+	}
+}
+
+// typeIdentity returns the string that is equal for identical types: the packages are
+// identified by the path, the names of the parameters and results are ignored.
+func typeIdentity(t Type) string {
+	qualified := func(pkg, pkgPath, name string) string {
+		if pkgPath != "" {
+			return pkgPath + "." + name
+		}
+		if pkg != "" {
+			return pkg + "." + name
+		}
+		return name
+	}
+	fieldsIdentity := func(fieldList []*Field, withNames bool) string {
+		return strings.Join(lo.Map(fieldList, func(item *Field, _ int) string {
+			if withNames {
+				return item.Name + " " + typeIdentity(item.Type)
+			}
+			return typeIdentity(item.Type)
+		}), ", ")
+	}
+
+	switch casted := t.(type) {
+	case nil:
+		return ""
+	case *Ident:
+		return qualified(casted.Package, casted.PackagePath, casted.Name)
+	case *SelectorExpr:
+		return qualified(casted.Package, casted.PackagePath, casted.Name)
+	case *StarExpr:
+		return "*" + typeIdentity(casted.Type)
+	case *ArrayType:
+		return "[" + casted.Len + "]" + typeIdentity(casted.Type)
+	case *EllipsisType:
+		return "..." + typeIdentity(casted.Type)
+	case *MapType:
+		return fmt.Sprintf("map[%s]%s", typeIdentity(casted.Key), typeIdentity(casted.Value))
+	case *ChanType:
+		return fmt.Sprintf("chan(%d) %s", casted.Direction, typeIdentity(casted.Type))
+	case *IndexExpr:
+		return fmt.Sprintf("%s[%s]", typeIdentity(casted.X), typeIdentity(casted.Index))
+	case *IndexListExpr:
+		indices := lo.Map(casted.Indices, func(item Type, _ int) string { return typeIdentity(item) })
+		return fmt.Sprintf("%s[%s]", typeIdentity(casted.X), strings.Join(indices, ", "))
+	case *FuncType:
+		return fmt.Sprintf("func(%s) (%s)", fieldsIdentity(casted.Params, false), fieldsIdentity(casted.Results, false))
+	case *StructType:
+		return fmt.Sprintf("struct{%s}", fieldsIdentity(casted.Fields, true))
+	case *InterfaceType:
+		return fmt.Sprintf("interface{%s}", fieldsIdentity(casted.Methods, true))
+	case *UnionExpr:
+		terms := lo.Map(casted.Terms, func(item Type, _ int) string { return typeIdentity(item) })
+		return strings.Join(terms, " | ")
+	case *TildeExpr:
+		return "~" + typeIdentity(casted.Type)
+	default:
+		return casted.ExprString()
+	}
+}
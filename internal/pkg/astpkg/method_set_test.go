@@ -0,0 +1,141 @@
+package astpkg
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetInterfaceMethods(t *testing.T) {
+	t.Parallel()
+
+	methodsString := func(list []*Field) []string {
+		return lo.Map(list, func(item *Field, _ int) string {
+			return item.Name + " " + item.Type.ExprString()
+		})
+	}
+
+	t.Run("embedded interfaces", func(t *testing.T) {
+		t.Parallel()
+
+		pkg, err := ParsePackage("github.com/khevse/codegen/tests/mainpkg")
+		require.NoError(t, err)
+
+		typeDecl, ok := pkg.TypeDeclList.GetByName("IEmbedded")
+		require.True(t, ok)
+
+		castedType, ok := CastToType[InterfaceType](typeDecl.Type)
+		require.True(t, ok)
+
+		list, err := GetInterfaceMethods(castedType)
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			[]string{
+				"String func() (_ string)",
+				"OtherMethod func() (_ any)",
+				"Close func() (_ error)",
+				"Name func() (_ string)",
+			},
+			methodsString(list),
+		)
+	})
+
+	stringMethod := func(result Type) *Field {
+		return &Field{
+			Name: "String",
+			Type: &FuncType{Params: []*Field{}, Results: []*Field{{Name: "", Type: result}}},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		Type    *InterfaceType
+		Want    []string
+		WantErr string
+	}{
+		"predeclared error": {
+			Type: &InterfaceType{
+				Methods: []*Field{
+					{Name: "", Type: &Ident{Name: "error"}},
+					{Name: "", Type: &Ident{Name: "comparable"}},
+				},
+			},
+			Want: []string{"Error func() (_ string)"},
+		},
+		"type set terms": {
+			Type: &InterfaceType{
+				Methods: []*Field{
+					{Name: "", Type: &UnionExpr{Terms: []Type{&TildeExpr{Type: &Ident{Name: "int"}}, &Ident{Name: "string"}}}},
+					stringMethod(&Ident{Name: "string"}),
+				},
+			},
+			Want: []string{"String func() (_ string)"},
+		},
+		"identical methods with different names of results": {
+			Type: &InterfaceType{
+				Methods: []*Field{
+					stringMethod(&Ident{Name: "string"}),
+					{
+						Name: "",
+						Type: &SelectorExpr{
+							Package:     "fmt",
+							PackagePath: "fmt",
+							Name:        "Stringer",
+							Type: &InterfaceType{
+								Methods: []*Field{
+									{
+										Name: "String",
+										Type: &FuncType{
+											Params:  []*Field{},
+											Results: []*Field{{Name: "res", Type: &Ident{Name: "string"}}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Want: []string{"String func() (_ string)"},
+		},
+		"conflict": {
+			Type: &InterfaceType{
+				Methods: []*Field{
+					stringMethod(&Ident{Name: "int"}),
+					{
+						Name: "",
+						Type: &SelectorExpr{
+							Package:     "fmt",
+							PackagePath: "fmt",
+							Name:        "Stringer",
+							Type:        &InterfaceType{Methods: []*Field{stringMethod(&Ident{Name: "string"})}},
+						},
+					},
+				},
+			},
+			WantErr: "method conflict: String func() (_ int) and (from fmt.Stringer) func() (_ string)",
+		},
+		"not resolved": {
+			Type: &InterfaceType{
+				Methods: []*Field{
+					{Name: "", Type: &SelectorExpr{Package: "fmt", PackagePath: "fmt", Name: "Stringer"}},
+				},
+			},
+			WantErr: "embedded type fmt.Stringer is not resolved",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			list, err := GetInterfaceMethods(tc.Type)
+			if tc.WantErr != "" {
+				require.EqualError(t, err, tc.WantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.Want, methodsString(list))
+		})
+	}
+}
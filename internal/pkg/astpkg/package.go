@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"slices"
+	"strings"
 
 	"github.com/samber/lo"
 	"golang.org/x/tools/go/packages"
@@ -15,15 +17,31 @@ type Package struct {
 	Dir          string
 	TypeDeclList TypeDeclList
 	FuncDeclList FuncDeclList
+	// PromotedFuncDeclList contains the methods promoted from the embedded fields of the structs.
+	PromotedFuncDeclList FuncDeclList
+	// Imports are the imports of the package files sorted by the path, the alias of the import
+	// without the explicit alias is the name of the imported package.
+	Imports ImportList
 }
 
 func ParsePackage(pkgName string) (*Package, error) {
+	pkgList, err := loadPackages(pkgName)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPackage(pkgName, pkgList)
+}
+
+func loadPackages(pkgName string) ([]*packages.Package, error) {
 	conf := &packages.Config{
 		Mode: packages.NeedFiles |
 			packages.NeedSyntax |
 			packages.NeedImports |
-			packages.LoadSyntax |
-			packages.LoadAllSyntax |
+			packages.NeedDeps |
+			packages.NeedTypes |
+			packages.NeedTypesInfo |
+			packages.NeedModule |
 			packages.NeedName,
 	}
 
@@ -36,6 +54,12 @@ func ParsePackage(pkgName string) (*Package, error) {
 		return nil, err
 	}
 
+	return pkgList, nil
+}
+
+// newPackage builds the package model from the loaded packages. The loaded packages
+// are not changed, so they can be used to build the model again.
+func newPackage(pkgName string, pkgList []*packages.Package) (*Package, error) {
 	var resPkg *Package
 
 	for _, pkg := range pkgList {
@@ -44,20 +68,23 @@ func ParsePackage(pkgName string) (*Package, error) {
 		}
 
 		resPkg = &Package{
-			Path:         pkg.ID,
-			Dir:          pkg.Dir,
-			TypeDeclList: nil,
-			FuncDeclList: nil,
+			Path:                 pkg.ID,
+			Dir:                  pkg.Dir,
+			TypeDeclList:         nil,
+			FuncDeclList:         nil,
+			PromotedFuncDeclList: nil,
 		}
+		resolver := newTypeResolver(pkg.Types, pkg.TypesInfo)
 		for _, file := range pkg.Syntax {
 			importList := NewImportList(file.Imports)
+			resPkg.Imports = append(resPkg.Imports, namedImports(importList, pkg.Imports)...)
 
 			for _, decl := range file.Decls {
 				switch castedDecl := decl.(type) {
 				case *ast.GenDecl:
 					if castedDecl.Tok == token.TYPE {
-						for _, ts := range NewTypeDeclList(pkg.PkgPath, castedDecl) {
-							err := InspectType(ts.Type, func(t Type) error {
+						for _, ts := range newTypeDeclList(pkg.PkgPath, castedDecl, resolver) {
+							err := InspectTypeDeclTypes(ts, func(t Type) error {
 								return SetPackageInformation(t, importList)
 							})
 							if err != nil {
@@ -68,7 +95,7 @@ func ParsePackage(pkgName string) (*Package, error) {
 						}
 					}
 				case *ast.FuncDecl:
-					funcDecl := NewFuncDecl(castedDecl)
+					funcDecl := newFuncDecl(castedDecl, resolver)
 					err := InspectFuncDeclFields(
 						funcDecl,
 						func(f *Field) error {
@@ -85,16 +112,151 @@ func ParsePackage(pkgName string) (*Package, error) {
 				}
 			}
 		}
+		resolver.resolve()
+		resPkg.PromotedFuncDeclList = resolver.promotedFuncDeclList(resPkg.FuncDeclList)
+		resPkg.Imports = sortedImports(lo.Uniq(resPkg.Imports))
 	}
 	if resPkg == nil {
 		return nil, errors.New("not found")
 	}
 
-	if err := setIdentTypes(resPkg); err != nil {
-		return nil, fmt.Errorf("set ident types: %w", err)
+	return resPkg, nil
+}
+
+// namedImports returns the imports with the name of the imported package as the alias
+// of the imports without the explicit alias.
+func namedImports(importList ImportList, loaded map[string]*packages.Package) ImportList {
+	return lo.Map(importList, func(item Import, _ int) Import {
+		if item.Alias != "" {
+			return item
+		}
+		if pkg, ok := loaded[item.Path]; ok && pkg.Name != "" {
+			return NewImport(pkg.Name, item.Path)
+		}
+		return NewImport(item.AliasFromPath(), item.Path)
+	})
+}
+
+func sortedImports(importList ImportList) ImportList {
+	slices.SortFunc(importList, func(a, b Import) int {
+		if res := strings.Compare(a.Path, b.Path); res != 0 {
+			return res
+		}
+		return strings.Compare(a.Alias, b.Alias)
+	})
+	return importList
+}
+
+// PackageFiles describes the go files of the package.
+type PackageFiles struct {
+	Path    string
+	Name    string
+	Dir     string
+	GoFiles []string
+	// TestGoFiles are the test files of the package.
+	TestGoFiles []string
+	// XTestGoFiles are the test files of the external test package (<name>_test).
+	XTestGoFiles []string
+}
+
+// ListPackageFiles returns the go files of the packages matched by the patterns (./...), sorted by the package path.
+func ListPackageFiles(patterns ...string) ([]PackageFiles, error) {
+	conf := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles,
+		Tests: true,
 	}
 
-	return resPkg, nil
+	pkgList, err := packages.Load(conf, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("load package:%w", err)
+	}
+
+	if err := getParsePackageError(pkgList); err != nil {
+		return nil, err
+	}
+
+	byPath := make(map[string]*PackageFiles, len(pkgList))
+	get := func(path, name, dir string) *PackageFiles {
+		item, ok := byPath[path]
+		if !ok {
+			item = &PackageFiles{Path: path, Name: name, Dir: dir}
+			byPath[path] = item
+		}
+		return item
+	}
+
+	for _, pkg := range pkgList {
+		switch {
+		case strings.HasSuffix(pkg.ID, ".test"):
+			// the generated main package of the test binary
+		case pkg.ID == pkg.PkgPath:
+			item := get(pkg.PkgPath, pkg.Name, pkg.Dir)
+			item.Name = pkg.Name
+			item.GoFiles = pkg.GoFiles
+		case strings.HasSuffix(pkg.PkgPath, "_test"):
+			// the files of the test variant (<path>_test [<path>.test]) contain only the test files
+			item := get(strings.TrimSuffix(pkg.PkgPath, "_test"), strings.TrimSuffix(pkg.Name, "_test"), pkg.Dir)
+			item.XTestGoFiles = pkg.GoFiles
+		default:
+			// the files of the test variant (<path> [<path>.test]) contain the files of the package
+			item := get(pkg.PkgPath, pkg.Name, pkg.Dir)
+			item.TestGoFiles = lo.Filter(pkg.GoFiles, func(file string, _ int) bool {
+				return strings.HasSuffix(file, "_test.go")
+			})
+		}
+	}
+
+	res := lo.Map(lo.Values(byPath), func(item *PackageFiles, _ int) PackageFiles { return *item })
+	slices.SortFunc(res, func(a, b PackageFiles) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return res, nil
+}
+
+// PackageDeps describes the package with its dependencies.
+type PackageDeps struct {
+	Path string
+	Name string
+	// Deps are the paths of the direct and indirect imports of the package, sorted.
+	Deps []string
+}
+
+// ListPackageDeps returns the packages matched by the patterns with their dependencies, sorted by the package path.
+func ListPackageDeps(patterns ...string) ([]PackageDeps, error) {
+	conf := &packages.Config{
+		Mode: packages.NeedName | packages.NeedImports | packages.NeedDeps,
+	}
+
+	pkgList, err := packages.Load(conf, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("load package:%w", err)
+	}
+
+	if err := getParsePackageError(pkgList); err != nil {
+		return nil, err
+	}
+
+	res := make([]PackageDeps, 0, len(pkgList))
+	for _, pkg := range pkgList {
+		deps := make([]string, 0)
+		packages.Visit(lo.Values(pkg.Imports), nil, func(item *packages.Package) {
+			deps = append(deps, item.PkgPath)
+		})
+		slices.Sort(deps)
+
+		res = append(res, PackageDeps{
+			Path: pkg.PkgPath,
+			Name: pkg.Name,
+			Deps: deps,
+		})
+	}
+
+	slices.SortFunc(res, func(a, b PackageDeps) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return res, nil
 }
 
 func GetPackagePath(pkgDir string) (string, error) {
@@ -134,7 +296,7 @@ func SetPackagePathForAllDecl(pkg *Package) error {
 		}
 	}
 
-	for _, decl := range pkg.FuncDeclList {
+	for _, decl := range append(pkg.FuncDeclList, pkg.PromotedFuncDeclList...) {
 		err := InspectFuncDeclFields(decl, func(f *Field) error {
 			return InspectType(
 				f.Type,
@@ -170,13 +332,21 @@ func GetAllPackagesImports(baseImportPath []string, packageList ...*Package) (Im
 	copy(allImports, baseImportPath)
 
 	for _, pkg := range packageList {
-		for _, decl := range pkg.FuncDeclList {
+		for _, decl := range append(pkg.FuncDeclList, pkg.PromotedFuncDeclList...) {
 			imports, err := GetFuncDeclAllImportPath(decl)
 			if err != nil {
 				return nil, fmt.Errorf("get func declaration all imports(%s): %w", decl, err)
 			}
 			allImports = append(allImports, imports...)
 		}
+
+		for _, decl := range pkg.TypeDeclList {
+			imports, err := GetTypeDeclAllImportPath(decl)
+			if err != nil {
+				return nil, fmt.Errorf("get type declaration all imports(%s): %w", decl, err)
+			}
+			allImports = append(allImports, imports...)
+		}
 	}
 
 	imports, err := NewImportListWithUniqAlias(allImports)
@@ -218,51 +388,3 @@ func getParsePackageError(pkgList []*packages.Package) error {
 
 	return err
 }
-
-func setIdentTypes(pkg *Package) error {
-	objectsTypes := make(map[string]Type)
-	for _, item := range pkg.TypeDeclList {
-		objectsTypes[item.Name] = item.Type
-	}
-
-	//nolint:unparam // result 0 (error) is always ni
-	setIdentType := func(t Type) error {
-		casted, ok := CastToType[Ident](t)
-		if !ok || casted.Type != nil {
-			return nil
-		}
-
-		typeByName, ok := objectsTypes[casted.Name]
-		if ok {
-			casted.Type = typeByName
-		}
-
-		return nil
-	}
-
-	for _, decl := range pkg.TypeDeclList {
-		err := InspectTypeDeclTypes(decl, func(t Type) error {
-			return InspectType(
-				t,
-				setIdentType,
-			)
-		})
-		if err != nil {
-			return fmt.Errorf("inspect type declaration(%s): %w", decl, err)
-		}
-	}
-
-	for _, decl := range pkg.FuncDeclList {
-		err := InspectFuncDeclFields(decl, func(f *Field) error {
-			return InspectType(
-				f.Type,
-				setIdentType,
-			)
-		})
-		if err != nil {
-			return fmt.Errorf("inspect function declaration(%s): %w", decl, err)
-		}
-	}
-
-	return nil
-}
@@ -0,0 +1,134 @@
+package astpkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// PackageCache shares the loaded packages between the parsing of the same package:
+// the package is loaded and type-checked once, the model is built for every call,
+// so the changes of one model do not affect the others.
+//
+// The package is loaded again if the go files of the package or of its dependencies
+// from the main module are changed, for example by the generated files.
+type PackageCache struct {
+	mu    sync.Mutex
+	items map[string]packageCacheItem
+}
+
+type packageCacheItem struct {
+	pkgList []*packages.Package
+	dirs    []string
+	state   string
+}
+
+func NewPackageCache() *PackageCache {
+	return &PackageCache{
+		mu:    sync.Mutex{},
+		items: make(map[string]packageCacheItem),
+	}
+}
+
+// ParsePackage parses the package using the cache. The package is parsed without
+// the cache if the cache is nil.
+func (c *PackageCache) ParsePackage(pkgName string) (*Package, error) {
+	if c == nil {
+		return ParsePackage(pkgName)
+	}
+
+	pkgList, err := c.load(pkgName)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPackage(pkgName, pkgList)
+}
+
+func (c *PackageCache) load(pkgName string) ([]*packages.Package, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if item, ok := c.items[pkgName]; ok {
+		state, err := getDirsState(item.dirs)
+		if err != nil {
+			return nil, fmt.Errorf("get package state: %w", err)
+		}
+
+		if state == item.state {
+			return item.pkgList, nil
+		}
+	}
+
+	pkgList, err := loadPackages(pkgName)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := getMainModuleDirs(pkgList)
+	state, err := getDirsState(dirs)
+	if err != nil {
+		return nil, fmt.Errorf("get package state: %w", err)
+	}
+
+	c.items[pkgName] = packageCacheItem{
+		pkgList: pkgList,
+		dirs:    dirs,
+		state:   state,
+	}
+
+	return pkgList, nil
+}
+
+// getMainModuleDirs returns the directories of the packages and their dependencies from the main module.
+func getMainModuleDirs(pkgList []*packages.Package) []string {
+	dirs := make(map[string]struct{})
+
+	packages.Visit(pkgList, nil, func(pkg *packages.Package) {
+		if pkg.Module == nil || !pkg.Module.Main || pkg.Dir == "" {
+			return
+		}
+
+		dirs[pkg.Dir] = struct{}{}
+	})
+
+	res := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		res = append(res, dir)
+	}
+	slices.Sort(res)
+
+	return res
+}
+
+// getDirsState returns the names, sizes and modification times of the go files of the directories.
+func getDirsState(dirs []string) (string, error) {
+	buf := strings.Builder{}
+
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return "", fmt.Errorf("read dir(%s): %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".go" {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return "", fmt.Errorf("get file info(%s): %w", entry.Name(), err)
+			}
+
+			fmt.Fprintf(&buf, "%s:%d:%d;", filepath.Join(dir, entry.Name()), info.Size(), info.ModTime().UnixNano())
+		}
+	}
+
+	return buf.String(), nil
+}
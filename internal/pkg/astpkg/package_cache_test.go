@@ -0,0 +1,55 @@
+package astpkg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageCache(t *testing.T) {
+	t.Parallel()
+
+	const pkgPath = "github.com/khevse/codegen/tests/mainpkg"
+
+	t.Run("reuse loaded packages", func(t *testing.T) {
+		cache := NewPackageCache()
+
+		pkg1, err := cache.ParsePackage(pkgPath)
+		require.NoError(t, err)
+		item, ok := cache.items[pkgPath]
+		require.True(t, ok)
+		require.NotEmpty(t, item.dirs)
+
+		pkg2, err := cache.ParsePackage(pkgPath)
+		require.NoError(t, err)
+		require.Same(t, &item.pkgList[0], &cache.items[pkgPath].pkgList[0])
+
+		// the models are not shared
+		require.NotSame(t, pkg1, pkg2)
+		require.Empty(t, cmp.Diff(pkg1, pkg2))
+	})
+
+	t.Run("reload changed packages", func(t *testing.T) {
+		cache := NewPackageCache()
+
+		_, err := cache.ParsePackage(pkgPath)
+		require.NoError(t, err)
+
+		item := cache.items[pkgPath]
+		item.state = "changed"
+		cache.items[pkgPath] = item
+
+		_, err = cache.ParsePackage(pkgPath)
+		require.NoError(t, err)
+		require.NotEqual(t, "changed", cache.items[pkgPath].state)
+	})
+
+	t.Run("nil cache", func(t *testing.T) {
+		var cache *PackageCache
+
+		pkg, err := cache.ParsePackage(pkgPath)
+		require.NoError(t, err)
+		require.Equal(t, pkgPath, pkg.Path)
+	})
+}
@@ -30,6 +30,40 @@ func TestParsePackage(t *testing.T) {
 		wantDir, err := filepath.Abs(filepath.Join(filepath.Dir(file), "../../..", "tests/mainpkg"))
 		require.NoError(t, err)
 
+		childStructType := &StructType{
+			Fields: []*Field{{Name: "FieldString", Type: &Ident{Name: "string"}}},
+		}
+		childInterfaceType := &InterfaceType{
+			Methods: []*Field{
+				{
+					Name: "",
+					Type: &SelectorExpr{
+						Package:     "",
+						PackagePath: "fmt",
+						Name:        "Stringer",
+						Type: &InterfaceType{
+							Methods: []*Field{
+								{
+									Name: "String",
+									Type: &FuncType{
+										Params:  []*Field{},
+										Results: []*Field{{Name: "", Type: &Ident{Name: "string"}}},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "OtherMethod",
+					Type: &FuncType{
+						Params:  []*Field{},
+						Results: []*Field{{Name: "", Type: &Ident{Name: "any"}}},
+					},
+				},
+			},
+		}
+
 		want := &Package{
 			Path: "github.com/khevse/codegen/tests/mainpkg",
 			Dir:  wantDir,
@@ -45,6 +79,7 @@ func TestParsePackage(t *testing.T) {
 									PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 									Package:     "",
 									Name:        "Struct",
+									Type:        childStructType,
 								},
 							},
 							{
@@ -78,13 +113,15 @@ func TestParsePackage(t *testing.T) {
 								PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 								Package:     "",
 								Name:        "Struct",
+								Type:        childStructType,
 							},
 						},
 					},
 				},
 				{
-					Receiver: "StructWithMethods",
-					Name:     "SetFieldStruct",
+					Receiver:        "StructWithMethods",
+					PointerReceiver: true,
+					Name:            "SetFieldStruct",
 					Params: []*Field{
 						{
 							Name: "val",
@@ -92,14 +129,16 @@ func TestParsePackage(t *testing.T) {
 								PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 								Package:     "childpkgalias",
 								Name:        "Struct",
+								Type:        childStructType,
 							},
 						},
 					},
 					Results: []*Field{},
 				},
 				{
-					Receiver: "StructWithMethods",
-					Name:     "SetAllFields",
+					Receiver:        "StructWithMethods",
+					PointerReceiver: true,
+					Name:            "SetAllFields",
 					Params: []*Field{
 						{
 							Name: "val",
@@ -127,8 +166,9 @@ func TestParsePackage(t *testing.T) {
 					Results: []*Field{},
 				},
 				{
-					Receiver: "StructWithMethods",
-					Name:     "SetFieldStringFromInterface",
+					Receiver:        "StructWithMethods",
+					PointerReceiver: true,
+					Name:            "SetFieldStringFromInterface",
 					Params: []*Field{
 						{
 							Name: "val",
@@ -136,12 +176,21 @@ func TestParsePackage(t *testing.T) {
 								PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 								Package:     "",
 								Name:        "Interface",
+								Type:        childInterfaceType,
 							},
 						},
 					},
 					Results: []*Field{},
 				},
 			},
+			PromotedFuncDeclList: FuncDeclList{},
+			Imports: ImportList{
+				NewImport("fmt", "fmt"),
+				NewImport("childpkg", "github.com/khevse/codegen/tests/mainpkg/childpkg"),
+				NewImport("childpkgalias", "github.com/khevse/codegen/tests/mainpkg/childpkg"),
+				NewImport("io", "io"),
+				NewImport("sync", "sync"),
+			},
 		}
 
 		pkg, err := ParsePackage("github.com/khevse/codegen/tests/mainpkg")
@@ -155,6 +204,7 @@ func TestParsePackage(t *testing.T) {
 				pkg.FuncDeclList = lo.Filter(pkg.FuncDeclList, func(item *FuncDecl, _ int) bool {
 					return item.Receiver == "StructWithMethods"
 				})
+				pkg.PromotedFuncDeclList = pkg.PromotedFuncDeclList.GetByReceiverName("StructWithMethods")
 				return pkg
 			}(),
 			cmpopts.SortSlices(func(i, j *TypeDecl) bool {
@@ -180,8 +230,12 @@ func TestGetPackageDir(t *testing.T) {
 	})
 
 	t.Run("invalid package", func(t *testing.T) {
+		_, file, _, _ := runtime.Caller(0)
+		pkgDir, err := filepath.Abs(filepath.Join(filepath.Dir(file), ".."))
+		require.NoError(t, err)
+
 		dir, err := GetPackagePath("./..")
-		require.EqualError(t, err, `package error(name=.): [no Go files in /home/jack/work/go/src/codegen/internal/pkg(position:)]`)
+		require.EqualError(t, err, `package error(name=.): [no Go files in `+pkgDir+`(position:)]`)
 		require.Empty(t, dir)
 	})
 
@@ -196,12 +250,69 @@ func TestGetPackageDir(t *testing.T) {
 	})
 }
 
+func TestListPackageDeps(t *testing.T) {
+	t.Parallel()
+
+	list, err := ListPackageDeps(
+		"github.com/khevse/codegen/tests/mainpkg/childpkg",
+		"github.com/khevse/codegen/tests/mainpkg",
+	)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	require.Equal(t, "github.com/khevse/codegen/tests/mainpkg", list[0].Path)
+	require.Equal(t, "mainpkg", list[0].Name)
+	require.Contains(t, list[0].Deps, "github.com/khevse/codegen/tests/mainpkg/childpkg")
+	require.Contains(t, list[0].Deps, "sync")
+	// the dependencies of the imports are included
+	require.Contains(t, list[0].Deps, "unicode/utf8")
+
+	require.Equal(t, "github.com/khevse/codegen/tests/mainpkg/childpkg", list[1].Path)
+	require.Equal(t, "childpkg", list[1].Name)
+	require.Contains(t, list[1].Deps, "fmt")
+	require.NotContains(t, list[1].Deps, "github.com/khevse/codegen/tests/mainpkg")
+}
+
 func TestSetPackagePathForAllDecl(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		_, file, _, _ := runtime.Caller(0)
 		wantDir, err := filepath.Abs(filepath.Join(filepath.Dir(file), "../../..", "tests/mainpkg"))
 		require.NoError(t, err)
 
+		childStructType := &StructType{
+			Fields: []*Field{{Name: "FieldString", Type: &Ident{Name: "string"}}},
+		}
+		childInterfaceType := &InterfaceType{
+			Methods: []*Field{
+				{
+					Name: "",
+					Type: &SelectorExpr{
+						Package:     "",
+						PackagePath: "fmt",
+						Name:        "Stringer",
+						Type: &InterfaceType{
+							Methods: []*Field{
+								{
+									Name: "String",
+									Type: &FuncType{
+										Params:  []*Field{},
+										Results: []*Field{{Name: "", Type: &Ident{Name: "string"}}},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "OtherMethod",
+					Type: &FuncType{
+						Params:  []*Field{},
+						Results: []*Field{{Name: "", Type: &Ident{Name: "any"}}},
+					},
+				},
+			},
+		}
+
 		want := &Package{
 			Path: "github.com/khevse/codegen/tests/mainpkg",
 			Dir:  wantDir,
@@ -217,6 +328,7 @@ func TestSetPackagePathForAllDecl(t *testing.T) {
 									PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 									Package:     "",
 									Name:        "Struct",
+									Type:        childStructType,
 								},
 							},
 							{
@@ -363,13 +475,15 @@ func TestSetPackagePathForAllDecl(t *testing.T) {
 								PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 								Package:     "",
 								Name:        "Struct",
+								Type:        childStructType,
 							},
 						},
 					},
 				},
 				{
-					Receiver: "StructWithMethods",
-					Name:     "SetFieldStruct",
+					Receiver:        "StructWithMethods",
+					PointerReceiver: true,
+					Name:            "SetFieldStruct",
 					Params: []*Field{
 						{
 							Name: "val",
@@ -377,14 +491,16 @@ func TestSetPackagePathForAllDecl(t *testing.T) {
 								PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 								Package:     "childpkgalias",
 								Name:        "Struct",
+								Type:        childStructType,
 							},
 						},
 					},
 					Results: []*Field{},
 				},
 				{
-					Receiver: "StructWithMethods",
-					Name:     "SetAllFields",
+					Receiver:        "StructWithMethods",
+					PointerReceiver: true,
+					Name:            "SetAllFields",
 					Params: []*Field{
 						{
 							Name: "val",
@@ -414,8 +530,9 @@ func TestSetPackagePathForAllDecl(t *testing.T) {
 					Results: []*Field{},
 				},
 				{
-					Receiver: "StructWithMethods",
-					Name:     "SetFieldStringFromInterface",
+					Receiver:        "StructWithMethods",
+					PointerReceiver: true,
+					Name:            "SetFieldStringFromInterface",
 					Params: []*Field{
 						{
 							Name: "val",
@@ -423,12 +540,21 @@ func TestSetPackagePathForAllDecl(t *testing.T) {
 								PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
 								Package:     "",
 								Name:        "Interface",
+								Type:        childInterfaceType,
 							},
 						},
 					},
 					Results: []*Field{},
 				},
 			},
+			PromotedFuncDeclList: FuncDeclList{},
+			Imports: ImportList{
+				NewImport("fmt", "fmt"),
+				NewImport("childpkg", "github.com/khevse/codegen/tests/mainpkg/childpkg"),
+				NewImport("childpkgalias", "github.com/khevse/codegen/tests/mainpkg/childpkg"),
+				NewImport("io", "io"),
+				NewImport("sync", "sync"),
+			},
 		}
 
 		pkg, err := ParsePackage("github.com/khevse/codegen/tests/mainpkg")
@@ -446,6 +572,7 @@ func TestSetPackagePathForAllDecl(t *testing.T) {
 				pkg.FuncDeclList = lo.Filter(pkg.FuncDeclList, func(item *FuncDecl, _ int) bool {
 					return item.Receiver == "StructWithMethods"
 				})
+				pkg.PromotedFuncDeclList = pkg.PromotedFuncDeclList.GetByReceiverName("StructWithMethods")
 				return pkg
 			}(),
 			cmpopts.SortSlices(func(i, j *TypeDecl) bool {
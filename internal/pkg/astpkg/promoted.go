@@ -0,0 +1,132 @@
+package astpkg
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// promotedFuncDeclList returns the methods promoted from the embedded fields of the
+// struct types declared in the package. The method set of the pointer to the type is
+// used, so the shadowing, depth and ambiguity rules are the rules of the language.
+// The methods which are not in the method set of the value have the pointer receiver.
+// The comments are taken from the declarations of the package.
+func (r *typeResolver) promotedFuncDeclList(funcDeclList FuncDeclList) FuncDeclList {
+	if r == nil || r.pkg == nil {
+		return nil
+	}
+
+	var res FuncDeclList
+
+	scope := r.pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() {
+			continue
+		}
+
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			continue
+		}
+
+		typeParams := make([]string, 0, named.TypeParams().Len())
+		for i := range named.TypeParams().Len() {
+			typeParams = append(typeParams, named.TypeParams().At(i).Obj().Name())
+		}
+
+		valueMethodSet := types.NewMethodSet(named)
+		methodSet := types.NewMethodSet(types.NewPointer(named))
+		for i := range methodSet.Len() {
+			selection := methodSet.At(i)
+			if len(selection.Index()) < 2 {
+				continue
+			}
+
+			signature, ok := selection.Type().(*types.Signature)
+			if !ok {
+				continue
+			}
+
+			funcType := r.convertSignature(signature, true)
+
+			var (
+				comment    string
+				directives DirectiveList
+			)
+			if source, ok := r.promotedMethodSource(selection, funcDeclList); ok {
+				comment, directives = source.Comment, source.Directives
+			}
+
+			res = append(res, &FuncDecl{
+				Receiver:           obj.Name(),
+				ReceiverTypeParams: lo.Ternary(len(typeParams) == 0, nil, typeParams),
+				PointerReceiver:    valueMethodSet.Lookup(selection.Obj().Pkg(), selection.Obj().Name()) == nil,
+				Name:               selection.Obj().Name(),
+				Comment:            comment,
+				Directives:         directives,
+				TypeParams:         nil,
+				Params:             funcType.Params,
+				Results:            funcType.Results,
+				PromotedFrom:       promotedFrom(named, selection.Index()),
+			})
+		}
+	}
+
+	return res
+}
+
+// promotedMethodSource returns the declaration of the promoted method if it is declared in the package.
+func (r *typeResolver) promotedMethodSource(selection *types.Selection, funcDeclList FuncDeclList) (*FuncDecl, bool) {
+	if selection.Obj().Pkg() != r.pkg {
+		return nil, false
+	}
+
+	signature, ok := selection.Obj().Type().(*types.Signature)
+	if !ok || signature.Recv() == nil {
+		return nil, false
+	}
+
+	recvType := signature.Recv().Type()
+	if pointer, ok := recvType.(*types.Pointer); ok {
+		recvType = pointer.Elem()
+	}
+
+	recv, ok := recvType.(*types.Named)
+	if !ok {
+		return nil, false
+	}
+
+	return lo.Find(funcDeclList, func(item *FuncDecl) bool {
+		return item.Receiver == recv.Obj().Name() && item.Name == selection.Obj().Name()
+	})
+}
+
+// promotedFrom returns the selector of the embedded field which the method is promoted from:
+// Base.Mutex for the method Lock of the type struct{ Base } where Base is struct{ sync.Mutex }.
+func promotedFrom(named *types.Named, index []int) string {
+	names := make([]string, 0, len(index)-1)
+
+	var t types.Type = named
+	for _, idx := range index[:len(index)-1] {
+		if pointer, ok := t.(*types.Pointer); ok {
+			t = pointer.Elem()
+		}
+
+		structType, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			break
+		}
+
+		field := structType.Field(idx)
+		names = append(names, field.Name())
+		t = field.Type()
+	}
+
+	return strings.Join(names, ".")
+}
@@ -0,0 +1,90 @@
+package astpkg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromotedFuncDeclList(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := ParsePackage("github.com/khevse/codegen/tests/mainpkg")
+	require.NoError(t, err)
+
+	stringType := &Ident{Name: "string"}
+
+	require.Empty(t, cmp.Diff(
+		FuncDeclList{
+			{
+				Receiver:        "BaseService",
+				PointerReceiver: true,
+				Name:            "Lock",
+				Params:          []*Field{},
+				Results:         []*Field{},
+				PromotedFrom:    "Mutex",
+			},
+			{
+				Receiver:        "BaseService",
+				PointerReceiver: true,
+				Name:            "TryLock",
+				Params:          []*Field{},
+				Results:         []*Field{{Name: "", Type: &Ident{Name: "bool"}}},
+				PromotedFrom:    "Mutex",
+			},
+			{
+				Receiver:        "BaseService",
+				PointerReceiver: true,
+				Name:            "Unlock",
+				Params:          []*Field{},
+				Results:         []*Field{},
+				PromotedFrom:    "Mutex",
+			},
+			{
+				Receiver:     "Service",
+				Name:         "Close",
+				Comment:      "Close comment",
+				Params:       []*Field{},
+				Results:      []*Field{{Name: "", Type: &Ident{Name: "error"}}},
+				PromotedFrom: "BaseService",
+			},
+			{
+				Receiver:     "Service",
+				Name:         "GetFieldString",
+				Params:       []*Field{},
+				Results:      []*Field{{Name: "", Type: stringType}},
+				PromotedFrom: "Struct",
+			},
+			{
+				Receiver:     "Service",
+				Name:         "Lock",
+				Params:       []*Field{},
+				Results:      []*Field{},
+				PromotedFrom: "BaseService.Mutex",
+			},
+			{
+				Receiver:     "Service",
+				Name:         "String",
+				Params:       []*Field{},
+				Results:      []*Field{{Name: "", Type: stringType}},
+				PromotedFrom: "IObject1",
+			},
+			{
+				Receiver:     "Service",
+				Name:         "TryLock",
+				Params:       []*Field{},
+				Results:      []*Field{{Name: "", Type: &Ident{Name: "bool"}}},
+				PromotedFrom: "BaseService.Mutex",
+			},
+			{
+				Receiver:     "Service",
+				Name:         "Unlock",
+				Params:       []*Field{},
+				Results:      []*Field{},
+				PromotedFrom: "BaseService.Mutex",
+			},
+		},
+		pkg.PromotedFuncDeclList,
+	))
+}
@@ -0,0 +1,345 @@
+package astpkg
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// PackageSchema is the serializable representation of the package model, it is encoded
+// to JSON and YAML with the stable order of the fields and the declarations.
+type PackageSchema struct {
+	Path          string           `json:"path" yaml:"path"`
+	Dir           string           `json:"dir" yaml:"dir"`
+	Imports       []ImportSchema   `json:"imports,omitempty" yaml:"imports,omitempty"`
+	Types         []TypeDeclSchema `json:"types" yaml:"types"`
+	Funcs         []FuncDeclSchema `json:"funcs" yaml:"funcs"`
+	PromotedFuncs []FuncDeclSchema `json:"promoted_funcs" yaml:"promoted_funcs"`
+}
+
+// ImportSchema is the import of the package files, Alias is the package name used in Expr.
+type ImportSchema struct {
+	Alias string `json:"alias" yaml:"alias"`
+	Path  string `json:"path" yaml:"path"`
+}
+
+type TypeDeclSchema struct {
+	Name        string            `json:"name" yaml:"name"`
+	Package     string            `json:"package,omitempty" yaml:"package,omitempty"`
+	PackagePath string            `json:"package_path,omitempty" yaml:"package_path,omitempty"`
+	Comment     string            `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Directives  []DirectiveSchema `json:"directives,omitempty" yaml:"directives,omitempty"`
+	TypeParams  []FieldSchema     `json:"type_params,omitempty" yaml:"type_params,omitempty"`
+	Type        *TypeSchema       `json:"type" yaml:"type"`
+}
+
+type FuncDeclSchema struct {
+	Name               string            `json:"name" yaml:"name"`
+	Receiver           string            `json:"receiver,omitempty" yaml:"receiver,omitempty"`
+	ReceiverTypeParams []string          `json:"receiver_type_params,omitempty" yaml:"receiver_type_params,omitempty"`
+	PointerReceiver    bool              `json:"pointer_receiver,omitempty" yaml:"pointer_receiver,omitempty"`
+	PromotedFrom       string            `json:"promoted_from,omitempty" yaml:"promoted_from,omitempty"`
+	Comment            string            `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Directives         []DirectiveSchema `json:"directives,omitempty" yaml:"directives,omitempty"`
+	TypeParams         []FieldSchema     `json:"type_params,omitempty" yaml:"type_params,omitempty"`
+	Params             []FieldSchema     `json:"params,omitempty" yaml:"params,omitempty"`
+	Results            []FieldSchema     `json:"results,omitempty" yaml:"results,omitempty"`
+}
+
+type DirectiveSchema struct {
+	Name string            `json:"name" yaml:"name"`
+	Args map[string]string `json:"args,omitempty" yaml:"args,omitempty"`
+}
+
+type FieldSchema struct {
+	Name       string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Directives []DirectiveSchema `json:"directives,omitempty" yaml:"directives,omitempty"`
+	Type       *TypeSchema       `json:"type" yaml:"type"`
+}
+
+// TypeSchema is the type expression. Kind is the name of the model type (ident, selector,
+// star, array, map, ellipsis, func, struct, interface, chan, index, index_list, union, tilde),
+// Expr is the expression of the type in the generated code qualified by the package aliases, Underlying is the resolved
+// underlying type of the named type, Len is the length of the array (empty for the slice).
+type TypeSchema struct {
+	Kind        string        `json:"kind" yaml:"kind"`
+	Expr        string        `json:"expr" yaml:"expr"`
+	Package     string        `json:"package,omitempty" yaml:"package,omitempty"`
+	PackagePath string        `json:"package_path,omitempty" yaml:"package_path,omitempty"`
+	Name        string        `json:"name,omitempty" yaml:"name,omitempty"`
+	IsAlias     bool          `json:"is_alias,omitempty" yaml:"is_alias,omitempty"`
+	TypeParam   bool          `json:"type_param,omitempty" yaml:"type_param,omitempty"`
+	Direction   string        `json:"direction,omitempty" yaml:"direction,omitempty"`
+	Len         string        `json:"len,omitempty" yaml:"len,omitempty"`
+	Elem        *TypeSchema   `json:"elem,omitempty" yaml:"elem,omitempty"`
+	Key         *TypeSchema   `json:"key,omitempty" yaml:"key,omitempty"`
+	Value       *TypeSchema   `json:"value,omitempty" yaml:"value,omitempty"`
+	Indices     []*TypeSchema `json:"indices,omitempty" yaml:"indices,omitempty"`
+	Terms       []*TypeSchema `json:"terms,omitempty" yaml:"terms,omitempty"`
+	Fields      []FieldSchema `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Methods     []FieldSchema `json:"methods,omitempty" yaml:"methods,omitempty"`
+	Params      []FieldSchema `json:"params,omitempty" yaml:"params,omitempty"`
+	Results     []FieldSchema `json:"results,omitempty" yaml:"results,omitempty"`
+	Underlying  *TypeSchema   `json:"underlying,omitempty" yaml:"underlying,omitempty"`
+}
+
+func NewPackageSchema(pkg *Package) PackageSchema {
+	return PackageSchema{
+		Path: pkg.Path,
+		Dir:  pkg.Dir,
+		Imports: lo.Map(pkg.Imports, func(item Import, _ int) ImportSchema {
+			return ImportSchema{Alias: item.Alias, Path: item.Path}
+		}),
+		Types: lo.Map(pkg.TypeDeclList, func(item *TypeDecl, _ int) TypeDeclSchema {
+			return NewTypeDeclSchema(item, pkg.Imports)
+		}),
+		Funcs:         newFuncDeclSchemaList(pkg.FuncDeclList, pkg.Imports),
+		PromotedFuncs: newFuncDeclSchemaList(pkg.PromotedFuncDeclList, pkg.Imports),
+	}
+}
+
+// NewTypeDeclSchema returns the schema of the type declaration, the imports are used to qualify
+// the type expressions.
+func NewTypeDeclSchema(typeDecl *TypeDecl, imports ImportList) TypeDeclSchema {
+	return TypeDeclSchema{
+		Name:        typeDecl.Name,
+		Package:     typeDecl.Package,
+		PackagePath: typeDecl.PackagePath,
+		Comment:     typeDecl.Comment,
+		Directives:  newDirectiveSchemaList(typeDecl.Directives),
+		TypeParams:  newFieldSchemaList(typeDecl.TypeParams, imports),
+		Type:        NewTypeSchema(typeDecl.Type, imports),
+	}
+}
+
+// NewFuncDeclSchema returns the schema of the func declaration, the imports are used to qualify
+// the type expressions.
+func NewFuncDeclSchema(funcDecl *FuncDecl, imports ImportList) FuncDeclSchema {
+	return FuncDeclSchema{
+		Name:               funcDecl.Name,
+		Receiver:           funcDecl.Receiver,
+		ReceiverTypeParams: funcDecl.ReceiverTypeParams,
+		PointerReceiver:    funcDecl.PointerReceiver,
+		PromotedFrom:       funcDecl.PromotedFrom,
+		Comment:            funcDecl.Comment,
+		Directives:         newDirectiveSchemaList(funcDecl.Directives),
+		TypeParams:         newFieldSchemaList(funcDecl.TypeParams, imports),
+		Params:             newFieldSchemaList(funcDecl.Params, imports),
+		Results:            newFieldSchemaList(funcDecl.Results, imports),
+	}
+}
+
+func newFuncDeclSchemaList(list FuncDeclList, imports ImportList) []FuncDeclSchema {
+	return lo.Map(list, func(item *FuncDecl, _ int) FuncDeclSchema { return NewFuncDeclSchema(item, imports) })
+}
+
+func newFieldSchemaList(list []*Field, imports ImportList) []FieldSchema {
+	if len(list) == 0 {
+		return nil
+	}
+
+	return lo.Map(list, func(item *Field, _ int) FieldSchema {
+		return FieldSchema{
+			Name:       item.Name,
+			Directives: newDirectiveSchemaList(item.Directives),
+			Type:       NewTypeSchema(item.Type, imports),
+		}
+	})
+}
+
+func newDirectiveSchemaList(list DirectiveList) []DirectiveSchema {
+	if len(list) == 0 {
+		return nil
+	}
+
+	return lo.Map(list, func(item Directive, _ int) DirectiveSchema {
+		return DirectiveSchema{Name: item.Name, Args: item.Args}
+	})
+}
+
+func newTypeSchemaList(list []Type, imports ImportList) []*TypeSchema {
+	return lo.Map(list, func(item Type, _ int) *TypeSchema { return NewTypeSchema(item, imports) })
+}
+
+// NewTypeSchema returns the schema of the type, nil if the type is nil. The type expression is qualified
+// by the alias of the import with the package path, by the base of the package path if it is not imported.
+func NewTypeSchema(t Type, imports ImportList) *TypeSchema {
+	if t == nil {
+		return nil
+	}
+
+	res := &TypeSchema{Expr: typeExpr(t, imports)}
+
+	switch casted := t.(type) {
+	case *Ident:
+		res.Kind = "ident"
+		res.Package = casted.Package
+		res.PackagePath = casted.PackagePath
+		res.Name = casted.Name
+		res.IsAlias = casted.IsAlias
+		res.TypeParam = casted.TypeParam
+		res.Underlying = NewTypeSchema(casted.Type, imports)
+	case *SelectorExpr:
+		res.Kind = "selector"
+		res.Package = casted.Package
+		res.PackagePath = casted.PackagePath
+		res.Name = casted.Name
+		res.IsAlias = casted.IsAlias
+		res.Underlying = NewTypeSchema(casted.Type, imports)
+	case *StarExpr:
+		res.Kind = "star"
+		res.Elem = NewTypeSchema(casted.Type, imports)
+	case *ArrayType:
+		res.Kind = "array"
+		res.Len = casted.Len
+		res.Elem = NewTypeSchema(casted.Type, imports)
+	case *EllipsisType:
+		res.Kind = "ellipsis"
+		res.Elem = NewTypeSchema(casted.Type, imports)
+	case *MapType:
+		res.Kind = "map"
+		res.Key = NewTypeSchema(casted.Key, imports)
+		res.Value = NewTypeSchema(casted.Value, imports)
+	case *FuncType:
+		res.Kind = "func"
+		res.Params = newFieldSchemaList(casted.Params, imports)
+		res.Results = newFieldSchemaList(casted.Results, imports)
+	case *StructType:
+		res.Kind = "struct"
+		res.Fields = newFieldSchemaList(casted.Fields, imports)
+	case *InterfaceType:
+		res.Kind = "interface"
+		res.Methods = newFieldSchemaList(casted.Methods, imports)
+	case *ChanType:
+		res.Kind = "chan"
+		res.Direction = chanDirection(casted.Direction)
+		res.Elem = NewTypeSchema(casted.Type, imports)
+	case *IndexExpr:
+		res.Kind = "index"
+		res.Elem = NewTypeSchema(casted.X, imports)
+		res.Indices = newTypeSchemaList([]Type{casted.Index}, imports)
+	case *IndexListExpr:
+		res.Kind = "index_list"
+		res.Elem = NewTypeSchema(casted.X, imports)
+		res.Indices = newTypeSchemaList(casted.Indices, imports)
+	case *UnionExpr:
+		res.Kind = "union"
+		res.Terms = newTypeSchemaList(casted.Terms, imports)
+	case *TildeExpr:
+		res.Kind = "tilde"
+		res.Elem = NewTypeSchema(casted.Type, imports)
+	default:
+		res.Kind = "unknown"
+	}
+
+	return res
+}
+
+// chanDirection returns the direction of the channel as it is stored in the model.
+func chanDirection(dir ast.ChanDir) string {
+	switch dir {
+	case ast.SEND | ast.RECV:
+		return "both"
+	case ast.SEND:
+		return "send"
+	case ast.RECV:
+		return "recv"
+	default:
+		return ""
+	}
+}
+
+// typeExpr returns the expression of the type qualified by the package aliases.
+func typeExpr(t Type, imports ImportList) string {
+	switch casted := t.(type) {
+	case *Ident:
+		return qualifiedName(casted.Package, casted.PackagePath, casted.Name, imports)
+	case *SelectorExpr:
+		return qualifiedName(casted.Package, casted.PackagePath, casted.Name, imports)
+	case *StarExpr:
+		return "*" + typeExpr(casted.Type, imports)
+	case *ArrayType:
+		return "[" + casted.Len + "]" + typeExpr(casted.Type, imports)
+	case *EllipsisType:
+		return "..." + typeExpr(casted.Type, imports)
+	case *MapType:
+		return fmt.Sprintf("map[%s]%s", typeExpr(casted.Key, imports), typeExpr(casted.Value, imports))
+	case *FuncType:
+		return "func" + signatureExpr(casted, imports)
+	case *StructType:
+		fields := lo.Map(casted.Fields, func(item *Field, _ int) string {
+			if item.Name == "" {
+				return typeExpr(item.Type, imports)
+			}
+			return fmt.Sprintf("%s %s", item.Name, typeExpr(item.Type, imports))
+		})
+		return fmt.Sprintf("struct{%s}", strings.Join(fields, "; "))
+	case *InterfaceType:
+		methods := lo.Map(casted.Methods, func(item *Field, _ int) string {
+			if funcType, ok := item.Type.(*FuncType); ok && item.Name != "" {
+				return item.Name + signatureExpr(funcType, imports)
+			}
+			return typeExpr(item.Type, imports)
+		})
+		return fmt.Sprintf("interface{%s}", strings.Join(methods, "; "))
+	case *ChanType:
+		switch casted.Direction {
+		case ast.SEND:
+			return "chan<- " + typeExpr(casted.Type, imports)
+		case ast.RECV:
+			return "<-chan " + typeExpr(casted.Type, imports)
+		default:
+			return "chan " + typeExpr(casted.Type, imports)
+		}
+	case *IndexExpr:
+		return fmt.Sprintf("%s[%s]", typeExpr(casted.X, imports), typeExpr(casted.Index, imports))
+	case *IndexListExpr:
+		indices := lo.Map(casted.Indices, func(item Type, _ int) string { return typeExpr(item, imports) })
+		return fmt.Sprintf("%s[%s]", typeExpr(casted.X, imports), strings.Join(indices, ", "))
+	case *UnionExpr:
+		terms := lo.Map(casted.Terms, func(item Type, _ int) string { return typeExpr(item, imports) })
+		return strings.Join(terms, " | ")
+	case *TildeExpr:
+		return "~" + typeExpr(casted.Type, imports)
+	default:
+		return t.ExprString()
+	}
+}
+
+// signatureExpr returns the parameters and the results of the func: (p []byte) (n int, err error).
+func signatureExpr(t *FuncType, imports ImportList) string {
+	fieldsExpr := func(list []*Field) string {
+		return strings.Join(lo.Map(list, func(item *Field, _ int) string {
+			if item.Name == "" {
+				return typeExpr(item.Type, imports)
+			}
+			return fmt.Sprintf("%s %s", item.Name, typeExpr(item.Type, imports))
+		}), ", ")
+	}
+
+	res := fmt.Sprintf("(%s)", fieldsExpr(t.Params))
+	switch {
+	case len(t.Results) == 0:
+		return res
+	case len(t.Results) == 1 && t.Results[0].Name == "":
+		return fmt.Sprintf("%s %s", res, fieldsExpr(t.Results))
+	default:
+		return fmt.Sprintf("%s (%s)", res, fieldsExpr(t.Results))
+	}
+}
+
+// qualifiedName returns the name qualified by the package alias, the dot import is not qualified.
+func qualifiedName(alias, pkgPath, name string, imports ImportList) string {
+	if alias == "" && pkgPath != "" {
+		imp, ok := imports.GetByPath(pkgPath)
+		alias = lo.Ternary(ok && imp.Alias != "", imp.Alias, NewImport("", pkgPath).AliasFromPath())
+	}
+
+	if alias == "" || alias == "." {
+		return name
+	}
+
+	return fmt.Sprintf("%s.%s", alias, name)
+}
@@ -0,0 +1,266 @@
+package astpkg
+
+import (
+	"encoding/json"
+	"go/ast"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTypeSchema(t *testing.T) {
+	t.Parallel()
+
+	imports := ImportList{NewImport("cmp", "cmp"), NewImport("yaml", "gopkg.in/yaml.v3")}
+
+	for name, tc := range map[string]struct {
+		Type Type
+		Want *TypeSchema
+	}{
+		"nil": {
+			Type: nil,
+			Want: nil,
+		},
+		"alias of selector": {
+			Type: &Ident{
+				Name:    "Alias",
+				IsAlias: true,
+				Type:    &SelectorExpr{Package: "p", PackagePath: "example.com/p", Name: "Interface", Type: &InterfaceType{}},
+			},
+			Want: &TypeSchema{
+				Kind:    "ident",
+				Expr:    "Alias",
+				Name:    "Alias",
+				IsAlias: true,
+				Underlying: &TypeSchema{
+					Kind:        "selector",
+					Expr:        "p.Interface",
+					Package:     "p",
+					PackagePath: "example.com/p",
+					Name:        "Interface",
+					Underlying:  &TypeSchema{Kind: "interface", Expr: "interface{}"},
+				},
+			},
+		},
+		"map of pointers": {
+			Type: &MapType{Key: &Ident{Name: "string"}, Value: &StarExpr{Type: &Ident{Name: "T", TypeParam: true}}},
+			Want: &TypeSchema{
+				Kind:  "map",
+				Expr:  "map[string]*T",
+				Key:   &TypeSchema{Kind: "ident", Expr: "string", Name: "string"},
+				Value: &TypeSchema{Kind: "star", Expr: "*T", Elem: &TypeSchema{Kind: "ident", Expr: "T", Name: "T", TypeParam: true}},
+			},
+		},
+		"func": {
+			Type: &FuncType{
+				Params:  []*Field{{Name: "args", Type: &EllipsisType{Type: &Ident{Name: "int"}}}},
+				Results: []*Field{{Type: &Ident{Name: "error"}}},
+			},
+			Want: &TypeSchema{
+				Kind: "func",
+				Expr: "func(args ...int) error",
+				Params: []FieldSchema{{
+					Name: "args",
+					Type: &TypeSchema{Kind: "ellipsis", Expr: "...int", Elem: &TypeSchema{Kind: "ident", Expr: "int", Name: "int"}},
+				}},
+				Results: []FieldSchema{{Type: &TypeSchema{Kind: "ident", Expr: "error", Name: "error"}}},
+			},
+		},
+		"chan": {
+			Type: &ChanType{Type: &ArrayType{Type: &Ident{Name: "byte"}}, Direction: ast.SEND | ast.RECV},
+			Want: &TypeSchema{
+				Kind:      "chan",
+				Expr:      "chan []byte",
+				Direction: "both",
+				Elem:      &TypeSchema{Kind: "array", Expr: "[]byte", Elem: &TypeSchema{Kind: "ident", Expr: "byte", Name: "byte"}},
+			},
+		},
+		"generic instantiation": {
+			Type: &IndexListExpr{X: &Ident{Name: "Pair"}, Indices: []Type{&Ident{Name: "int"}, &Ident{Name: "string"}}},
+			Want: &TypeSchema{
+				Kind: "index_list",
+				Expr: "Pair[int, string]",
+				Elem: &TypeSchema{Kind: "ident", Expr: "Pair", Name: "Pair"},
+				Indices: []*TypeSchema{
+					{Kind: "ident", Expr: "int", Name: "int"},
+					{Kind: "ident", Expr: "string", Name: "string"},
+				},
+			},
+		},
+		"constraint": {
+			Type: &UnionExpr{Terms: []Type{&TildeExpr{Type: &Ident{Name: "int"}}, &Ident{Name: "string"}}},
+			Want: &TypeSchema{
+				Kind: "union",
+				Expr: "~int | string",
+				Terms: []*TypeSchema{
+					{Kind: "tilde", Expr: "~int", Elem: &TypeSchema{Kind: "ident", Expr: "int", Name: "int"}},
+					{Kind: "ident", Expr: "string", Name: "string"},
+				},
+			},
+		},
+		"qualified by imports": {
+			Type: &IndexExpr{
+				X:     &Ident{Name: "Set"},
+				Index: &SelectorExpr{PackagePath: "gopkg.in/yaml.v3", Name: "Node"},
+			},
+			Want: &TypeSchema{
+				Kind:    "index",
+				Expr:    "Set[yaml.Node]",
+				Elem:    &TypeSchema{Kind: "ident", Expr: "Set", Name: "Set"},
+				Indices: []*TypeSchema{{Kind: "selector", Expr: "yaml.Node", PackagePath: "gopkg.in/yaml.v3", Name: "Node"}},
+			},
+		},
+		"qualified by path": {
+			Type: &StarExpr{Type: &SelectorExpr{PackagePath: "example.com/p", Name: "Store"}},
+			Want: &TypeSchema{
+				Kind: "star",
+				Expr: "*p.Store",
+				Elem: &TypeSchema{Kind: "selector", Expr: "p.Store", PackagePath: "example.com/p", Name: "Store"},
+			},
+		},
+		"interface with union and method": {
+			Type: &InterfaceType{Methods: []*Field{
+				{Type: &UnionExpr{Terms: []Type{&TildeExpr{Type: &Ident{Name: "int"}}, &SelectorExpr{PackagePath: "cmp", Name: "Ordered"}}}},
+				{Name: "Less", Type: &FuncType{
+					Params:  []*Field{{Name: "a", Type: &Ident{Name: "T", TypeParam: true}}, {Name: "b", Type: &Ident{Name: "T", TypeParam: true}}},
+					Results: []*Field{{Type: &Ident{Name: "bool"}}},
+				}},
+			}},
+			Want: &TypeSchema{
+				Kind: "interface",
+				Expr: "interface{~int | cmp.Ordered; Less(a T, b T) bool}",
+				Methods: []FieldSchema{
+					{Type: &TypeSchema{
+						Kind: "union",
+						Expr: "~int | cmp.Ordered",
+						Terms: []*TypeSchema{
+							{Kind: "tilde", Expr: "~int", Elem: &TypeSchema{Kind: "ident", Expr: "int", Name: "int"}},
+							{Kind: "selector", Expr: "cmp.Ordered", PackagePath: "cmp", Name: "Ordered"},
+						},
+					}},
+					{Name: "Less", Type: &TypeSchema{
+						Kind: "func",
+						Expr: "func(a T, b T) bool",
+						Params: []FieldSchema{
+							{Name: "a", Type: &TypeSchema{Kind: "ident", Expr: "T", Name: "T", TypeParam: true}},
+							{Name: "b", Type: &TypeSchema{Kind: "ident", Expr: "T", Name: "T", TypeParam: true}},
+						},
+						Results: []FieldSchema{{Type: &TypeSchema{Kind: "ident", Expr: "bool", Name: "bool"}}},
+					}},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := NewTypeSchema(tc.Type, imports)
+			require.Empty(t, cmp.Diff(tc.Want, got))
+		})
+	}
+}
+
+func TestNewPackageSchema(t *testing.T) {
+	t.Parallel()
+
+	pkg := &Package{
+		Path: "example.com/p",
+		Dir:  "/src/p",
+		Imports: ImportList{
+			NewImport("ctx", "context"),
+		},
+		TypeDeclList: TypeDeclList{
+			{
+				Name:        "Store",
+				Package:     "p",
+				PackagePath: "example.com/p",
+				Comment:     "Store is the store.",
+				Directives:  DirectiveList{{Name: "interface", Args: map[string]string{"name": "IStore"}}},
+				Type: &StructType{Fields: []*Field{
+					{Name: "items", Type: &ArrayType{Type: &Ident{Name: "string"}}},
+					{Name: "ctx", Type: &SelectorExpr{PackagePath: "context", Name: "Context"}},
+				}},
+			},
+		},
+		FuncDeclList: FuncDeclList{
+			{
+				Receiver: "Store",
+				Name:     "Len",
+				Results:  []*Field{{Type: &Ident{Name: "int"}}},
+			},
+		},
+	}
+
+	data, err := json.MarshalIndent(NewPackageSchema(pkg), "", "  ")
+	require.NoError(t, err)
+	require.Equal(t, `{
+  "path": "example.com/p",
+  "dir": "/src/p",
+  "imports": [
+    {
+      "alias": "ctx",
+      "path": "context"
+    }
+  ],
+  "types": [
+    {
+      "name": "Store",
+      "package": "p",
+      "package_path": "example.com/p",
+      "comment": "Store is the store.",
+      "directives": [
+        {
+          "name": "interface",
+          "args": {
+            "name": "IStore"
+          }
+        }
+      ],
+      "type": {
+        "kind": "struct",
+        "expr": "struct{items []string; ctx ctx.Context}",
+        "fields": [
+          {
+            "name": "items",
+            "type": {
+              "kind": "array",
+              "expr": "[]string",
+              "elem": {
+                "kind": "ident",
+                "expr": "string",
+                "name": "string"
+              }
+            }
+          },
+          {
+            "name": "ctx",
+            "type": {
+              "kind": "selector",
+              "expr": "ctx.Context",
+              "package_path": "context",
+              "name": "Context"
+            }
+          }
+        ]
+      }
+    }
+  ],
+  "funcs": [
+    {
+      "name": "Len",
+      "receiver": "Store",
+      "results": [
+        {
+          "type": {
+            "kind": "ident",
+            "expr": "int",
+            "name": "int"
+          }
+        }
+      ]
+    }
+  ],
+  "promoted_funcs": []
+}`, string(data))
+}
@@ -3,6 +3,8 @@ package astpkg
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
 	"strings"
 	"unicode"
 
@@ -21,6 +23,9 @@ var (
 	_ Type = (*InterfaceType)(nil)
 	_ Type = (*ChanType)(nil)
 	_ Type = (*IndexExpr)(nil)
+	_ Type = (*IndexListExpr)(nil)
+	_ Type = (*UnionExpr)(nil)
+	_ Type = (*TildeExpr)(nil)
 
 	_ PackageGetterType = (*Ident)(nil)
 	_ PackageGetterType = (*SelectorExpr)(nil)
@@ -54,6 +59,8 @@ type Ident struct {
 	PackagePath string
 	Name        string
 	Type        Type
+	IsAlias     bool
+	TypeParam   bool
 }
 
 func (t Ident) String() string { return fmt.Sprintf("Ident(%s)", t.ExprString()) }
@@ -84,12 +91,15 @@ func (t StarExpr) String() string      { return fmt.Sprintf("StarExpr(%s)", t.Ex
 func (t StarExpr) ExprString() string  { return fmt.Sprintf("*%s", t.Type.ExprString()) }
 func (t StarExpr) Imports() ImportList { return t.Type.Imports() }
 
+// ArrayType is the slice or the array type.
 type ArrayType struct {
+	// Len is the length of the array, it is empty for the slice.
+	Len  string
 	Type Type
 }
 
 func (t ArrayType) String() string      { return fmt.Sprintf("ArrayType(%s)", t.ExprString()) }
-func (t ArrayType) ExprString() string  { return fmt.Sprintf("[]%s", t.Type.ExprString()) }
+func (t ArrayType) ExprString() string  { return fmt.Sprintf("[%s]%s", t.Len, t.Type.ExprString()) }
 func (t ArrayType) Imports() ImportList { return t.Type.Imports() }
 
 type MapType struct {
@@ -109,6 +119,7 @@ type SelectorExpr struct {
 	PackagePath string
 	Name        string
 	Type        Type
+	IsAlias     bool
 }
 
 func (t SelectorExpr) String() string {
@@ -277,35 +288,80 @@ func (t IndexExpr) ExprString() string {
 
 func (t IndexExpr) Imports() ImportList { return append(t.Index.Imports(), t.X.Imports()...) }
 
+type IndexListExpr struct {
+	Indices []Type
+	X       Type
+}
+
+func (t IndexListExpr) String() string { return fmt.Sprintf("IndexListExpr(%s)", t.ExprString()) }
+func (t IndexListExpr) ExprString() string {
+	indices := lo.Map(t.Indices, func(item Type, _ int) string { return item.ExprString() })
+	return fmt.Sprintf("%s[%s]", t.X.ExprString(), strings.Join(indices, ", "))
+}
+
+func (t IndexListExpr) Imports() ImportList {
+	imports := make(ImportList, 0)
+	for _, item := range t.Indices {
+		imports = append(imports, item.Imports()...)
+	}
+	return append(imports, t.X.Imports()...)
+}
+
+type UnionExpr struct {
+	Terms []Type
+}
+
+func (t UnionExpr) String() string { return fmt.Sprintf("UnionExpr(%s)", t.ExprString()) }
+func (t UnionExpr) ExprString() string {
+	terms := lo.Map(t.Terms, func(item Type, _ int) string { return item.ExprString() })
+	return strings.Join(terms, " | ")
+}
+
+func (t UnionExpr) Imports() ImportList {
+	imports := make(ImportList, 0)
+	for _, item := range t.Terms {
+		imports = append(imports, item.Imports()...)
+	}
+	return imports
+}
+
+type TildeExpr struct {
+	Type Type
+}
+
+func (t TildeExpr) String() string      { return fmt.Sprintf("TildeExpr(%s)", t.ExprString()) }
+func (t TildeExpr) ExprString() string  { return fmt.Sprintf("~%s", t.Type.ExprString()) }
+func (t TildeExpr) Imports() ImportList { return t.Type.Imports() }
+
 func NewType(expr ast.Expr) Type {
+	return newType(expr, nil)
+}
+
+func newType(expr ast.Expr, resolver *typeResolver) Type {
 	switch casted := expr.(type) {
 	case *ast.Ident:
-		var typeSpec Type
-
-		if obj := casted.Obj; obj != nil && obj.Decl != nil {
-			if spec, ok := obj.Decl.(*ast.TypeSpec); ok {
-				typeSpec = NewType(spec.Type)
-			}
-		}
-
-		return &Ident{
+		ident := &Ident{
 			Package:     "",
 			PackagePath: "",
 			Name:        casted.Name,
-			Type:        typeSpec,
+			Type:        nil,
 		}
+		resolver.track(ident, casted)
+
+		return ident
 	case *ast.StarExpr:
 		return &StarExpr{
-			Type: NewType(casted.X),
+			Type: newType(casted.X, resolver),
 		}
 	case *ast.ArrayType:
 		return &ArrayType{
-			Type: NewType(casted.Elt),
+			Len:  resolver.arrayLen(casted),
+			Type: newType(casted.Elt, resolver),
 		}
 	case *ast.MapType:
 		return &MapType{
-			Key:   NewType(casted.Key),
-			Value: NewType(casted.Value),
+			Key:   newType(casted.Key, resolver),
+			Value: newType(casted.Value, resolver),
 		}
 	case *ast.SelectorExpr:
 		var pkg string
@@ -315,48 +371,81 @@ func NewType(expr ast.Expr) Type {
 			}
 		}
 
-		if obj := casted.Sel.Obj; obj != nil && obj.Decl != nil {
-			if typeSpec, ok := obj.Decl.(*ast.TypeSpec); ok {
-				return &SelectorExpr{
-					Package:     pkg,
-					PackagePath: "",
-					Name:        typeSpec.Name.Name,
-					Type:        NewType(typeSpec.Type),
-				}
-			}
-		}
-
-		return &SelectorExpr{
+		selector := &SelectorExpr{
 			Package:     pkg,
 			PackagePath: "",
 			Name:        casted.Sel.Name,
 			Type:        nil,
 		}
+		resolver.track(selector, casted.Sel)
+
+		return selector
 	case *ast.IndexExpr:
+		x := newType(casted.X, resolver)
+		resolver.trackInstance(x, casted)
+
 		return &IndexExpr{
-			Index: NewType(casted.Index),
-			X:     NewType(casted.X),
+			Index: newType(casted.Index, resolver),
+			X:     x,
+		}
+	case *ast.IndexListExpr:
+		indices := lo.Map(casted.Indices, func(item ast.Expr, _ int) Type {
+			return newType(item, resolver)
+		})
+		x := newType(casted.X, resolver)
+		resolver.trackInstance(x, casted)
+
+		return &IndexListExpr{
+			Indices: indices,
+			X:       x,
+		}
+	case *ast.BinaryExpr:
+		if casted.Op != token.OR {
+			panic(fmt.Sprintf("unknown type: %+[1]v(%[1]T)", casted))
+		}
+
+		terms := make([]Type, 0, 2)
+		for _, item := range []Type{newType(casted.X, resolver), newType(casted.Y, resolver)} {
+			if union, ok := item.(*UnionExpr); ok {
+				terms = append(terms, union.Terms...)
+			} else {
+				terms = append(terms, item)
+			}
+		}
+
+		return &UnionExpr{
+			Terms: terms,
 		}
+	case *ast.UnaryExpr:
+		if casted.Op != token.TILDE {
+			panic(fmt.Sprintf("unknown type: %+[1]v(%[1]T)", casted))
+		}
+
+		return &TildeExpr{
+			Type: newType(casted.X, resolver),
+		}
+	case *ast.ParenExpr:
+		return newType(casted.X, resolver)
 	case *ast.Ellipsis:
 		return &EllipsisType{
-			Type: NewType(casted.Elt),
+			Type: newType(casted.Elt, resolver),
 		}
 	case *ast.FuncType:
 		return &FuncType{
-			Params:  NewFieldList(casted.Params),
-			Results: NewFieldList(casted.Results),
+			Params:  newFieldList(casted.Params, resolver),
+			Results: newFieldList(casted.Results, resolver),
 		}
 	case *ast.StructType:
 		return &StructType{
-			Fields: NewFieldList(casted.Fields),
+			Fields: newFieldList(casted.Fields, resolver),
 		}
 	case *ast.InterfaceType:
 		return &InterfaceType{
-			Methods: NewFieldList(casted.Methods),
+			Methods: newFieldList(casted.Methods, resolver),
 		}
 	case *ast.ChanType:
 		return &ChanType{
-			Type:      NewType(casted.Value),
+			Type:      newType(casted.Value, resolver),
 			Direction: casted.Dir,
 		}
 	default:
@@ -403,6 +492,11 @@ func InspectType(t Type, fn func(Type) error) error {
 		}
 		return inspectSelf(casted)
 	case *SelectorExpr:
+		if casted.Type != nil {
+			if err := InspectType(casted.Type, fn); err != nil {
+				return fmt.Errorf("SelectorExpr(%s): %w", casted, err)
+			}
+		}
 		return inspectSelf(casted)
 	case *IndexExpr:
 		if err := InspectType(casted.Index, fn); err != nil {
@@ -412,6 +506,28 @@ func InspectType(t Type, fn func(Type) error) error {
 			return fmt.Errorf("IndexExpr X(%s): %w", casted, err)
 		}
 		return inspectSelf(casted)
+	case *IndexListExpr:
+		for _, item := range casted.Indices {
+			if err := InspectType(item, fn); err != nil {
+				return fmt.Errorf("IndexListExpr index(%s): %w", casted, err)
+			}
+		}
+		if err := InspectType(casted.X, fn); err != nil {
+			return fmt.Errorf("IndexListExpr X(%s): %w", casted, err)
+		}
+		return inspectSelf(casted)
+	case *UnionExpr:
+		for _, item := range casted.Terms {
+			if err := InspectType(item, fn); err != nil {
+				return fmt.Errorf("UnionExpr term(%s): %w", casted, err)
+			}
+		}
+		return inspectSelf(casted)
+	case *TildeExpr:
+		if err := InspectType(casted.Type, fn); err != nil {
+			return fmt.Errorf("TildeExpr(%s): %w", casted, err)
+		}
+		return inspectSelf(casted)
 	case *EllipsisType:
 		err := InspectType(casted.Type, fn)
 		if err != nil {
@@ -488,6 +604,42 @@ func ReplaceImportAliasByImportPath(t Type, importList ImportList) error {
 	})
 }
 
+// RenameTypeParams renames the type parameters of the type by the names: old name -> new name.
+// The names are replaced at once, so the type parameters can be swapped. The new name used
+// by the type which is not renamed is an error, the type is not changed in this case.
+func RenameTypeParams(t Type, names map[string]string) error {
+	newNames := make(map[string]string, len(names))
+	for oldName, newName := range names {
+		newNames[newName] = oldName
+	}
+
+	var idents []*Ident
+	err := InspectType(t, func(t Type) error {
+		casted, ok := t.(*Ident)
+		if !ok || casted.Package != "" || casted.PackagePath != "" {
+			return nil
+		}
+
+		if _, ok := names[casted.Name]; ok && casted.Type == nil {
+			idents = append(idents, casted)
+		} else if oldName, ok := newNames[casted.Name]; ok {
+			return fmt.Errorf("rename type param %s: the name %s is already used", oldName, casted.Name)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the shared identifiers are renamed once
+	for _, item := range lo.Uniq(idents) {
+		item.Name = names[item.Name]
+	}
+
+	return nil
+}
+
 func IsExported(name string) bool {
 	var firstChar rune
 	if len(name) > 0 {
@@ -514,7 +666,18 @@ func inspectFieldsTypes(fieldList []*Field, fn func(Type) error) error {
 	)
 }
 
+// isBaseType reports whether the type is a predeclared type or a type parameter,
+// such types never have a package.
 func isBaseType(t Type) bool {
 	casted, ok := t.(*Ident)
-	return ok && casted.Type == nil
+	if !ok || casted.Package != "" || casted.PackagePath != "" {
+		return false
+	}
+
+	if casted.TypeParam {
+		return true
+	}
+
+	_, isPredeclared := types.Universe.Lookup(casted.Name).(*types.TypeName)
+	return casted.Type == nil && isPredeclared
 }
@@ -13,7 +13,10 @@ type TypeDecl struct {
 	PackagePath string
 	Name        string
 	Comment     string
+	TypeParams  []*Field
 	Type        Type
+	// Directives are the codegen directives of the doc comment, they are not included to the Comment.
+	Directives DirectiveList
 }
 
 func (t TypeDecl) String() string {
@@ -25,16 +28,24 @@ func (t TypeDecl) String() string {
 }
 
 func (t TypeDecl) GetFieldsImports() ImportList {
-	return lo.Uniq(t.Type.Imports())
+	imports := t.Type.Imports()
+	for _, field := range t.TypeParams {
+		imports = append(imports, field.Type.Imports()...)
+	}
+	return lo.Uniq(imports)
 }
 
 type TypeDeclList []*TypeDecl
 
 func NewTypeDeclList(pkg string, generalDecl *ast.GenDecl) TypeDeclList {
+	return newTypeDeclList(pkg, generalDecl, nil)
+}
+
+func newTypeDeclList(pkg string, generalDecl *ast.GenDecl, resolver *typeResolver) TypeDeclList {
 	imp := NewImportWithAlias(pkg)
 	list := make(TypeDeclList, 0, len(generalDecl.Specs))
 	for _, spec := range generalDecl.Specs {
-		ts, ok := NewTypeDecl(imp, generalDecl, spec)
+		ts, ok := newTypeDecl(imp, generalDecl, spec, resolver)
 		if ok {
 			list = append(list, ts)
 		}
@@ -50,6 +61,15 @@ func (l TypeDeclList) GetByName(name string) (*TypeDecl, bool) {
 }
 
 func NewTypeDecl(imp Import, generalDecl *ast.GenDecl, spec ast.Spec) (*TypeDecl, bool) {
+	return newTypeDecl(imp, generalDecl, spec, nil)
+}
+
+func newTypeDecl(
+	imp Import,
+	generalDecl *ast.GenDecl,
+	spec ast.Spec,
+	resolver *typeResolver,
+) (*TypeDecl, bool) {
 	castedSpec, isTypeSpec := spec.(*ast.TypeSpec)
 	if !isTypeSpec {
 		return nil, false
@@ -58,44 +78,61 @@ func NewTypeDecl(imp Import, generalDecl *ast.GenDecl, spec ast.Spec) (*TypeDecl
 	var specType Type
 	switch castedType := castedSpec.Type.(type) {
 	case *ast.StructType:
-		specType = NewType(castedType)
+		specType = newType(castedType, resolver)
 	case *ast.InterfaceType:
-		specType = NewType(castedType)
+		specType = newType(castedType, resolver)
 	case *ast.FuncType:
-		specType = NewType(castedType)
+		specType = newType(castedType, resolver)
 	case *ast.ArrayType:
-		specType = NewType(castedType)
+		specType = newType(castedType, resolver)
 	case *ast.MapType:
-		specType = NewType(castedType)
+		specType = newType(castedType, resolver)
 	case *ast.Ident:
-		specType = NewType(castedType)
+		specType = newType(castedType, resolver)
 	case *ast.ChanType:
-		specType = NewType(castedType)
+		specType = newType(castedType, resolver)
 	case *ast.SelectorExpr:
-		specType = NewType(castedType)
+		specType = newType(castedType, resolver)
+	case *ast.StarExpr:
+		specType = newType(castedType, resolver)
+	case *ast.IndexExpr:
+		specType = newType(castedType, resolver)
+	case *ast.IndexListExpr:
+		specType = newType(castedType, resolver)
 	default:
 		panic(fmt.Sprintf("unknown type: %+[1]v(%[1]T)", castedType))
 	}
 
 	specName := castedSpec.Name.Name
 
-	var specComment string
+	var (
+		specComment    string
+		specDirectives DirectiveList
+	)
 	if doc := castedSpec.Doc; doc != nil {
 		specComment = doc.Text()
+		specDirectives = newDirectiveList(doc)
 	} else if len(generalDecl.Specs) == 1 && generalDecl.Doc != nil {
 		specComment = generalDecl.Doc.Text()
+		specDirectives = newDirectiveList(generalDecl.Doc)
 	}
 
 	return &TypeDecl{
 		Name:        specName,
 		Comment:     strings.TrimSpace(specComment),
+		TypeParams:  newTypeParamList(castedSpec.TypeParams, resolver),
 		Type:        specType,
+		Directives:  specDirectives,
 		Package:     imp.Alias,
 		PackagePath: imp.Path,
 	}, true
 }
 
 func InspectTypeDeclTypes(typeDecl *TypeDecl, fn func(Type) error) error {
+	if err := inspectFieldsTypes(typeDecl.TypeParams, fn); err != nil {
+		return fmt.Errorf("inspect type params: %w", err)
+	}
+
 	return InspectType(
 		typeDecl.Type,
 		fn,
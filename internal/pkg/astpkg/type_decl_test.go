@@ -288,4 +288,102 @@ func TestNewTypeDeclList(t *testing.T) {
 			typeDeclList,
 		)
 	})
+
+	t.Run("generic struct", func(t *testing.T) {
+		typeDeclList := getTypeDeclList(
+			t,
+			`package p;
+
+			type Cache[K comparable, V any] struct{ items map[K]V }`,
+		)
+		require.Equal(
+			t,
+			[]*TypeDecl{
+				{
+					Name:    "Cache",
+					Comment: "",
+					TypeParams: []*Field{
+						{Name: "K", Type: &Ident{Name: "comparable"}},
+						{Name: "V", Type: &Ident{Name: "any"}},
+					},
+					Type: &StructType{
+						Fields: []*Field{
+							{
+								Name: "items",
+								Type: &MapType{Key: &Ident{Name: "K"}, Value: &Ident{Name: "V"}},
+							},
+						},
+					},
+					Package:     "test",
+					PackagePath: "./test",
+				},
+			},
+			typeDeclList,
+		)
+	})
+
+	t.Run("constraint interface", func(t *testing.T) {
+		typeDeclList := getTypeDeclList(
+			t,
+			`package p;
+
+			type Number interface{ ~int | (int64) | ~float64 }`,
+		)
+		require.Equal(
+			t,
+			[]*TypeDecl{
+				{
+					Name:    "Number",
+					Comment: "",
+					Type: &InterfaceType{
+						Methods: []*Field{
+							{
+								Name: "",
+								Type: &UnionExpr{
+									Terms: []Type{
+										&TildeExpr{Type: &Ident{Name: "int"}},
+										&Ident{Name: "int64"},
+										&TildeExpr{Type: &Ident{Name: "float64"}},
+									},
+								},
+							},
+						},
+					},
+					Package:     "test",
+					PackagePath: "./test",
+				},
+			},
+			typeDeclList,
+		)
+		require.Equal(
+			t,
+			"~int | int64 | ~float64",
+			typeDeclList[0].Type.(*InterfaceType).Methods[0].Type.ExprString(),
+		)
+	})
+
+	t.Run("generic instantiation", func(t *testing.T) {
+		typeDeclList := getTypeDeclList(
+			t,
+			`package p;
+
+			type StringCache = p2.Cache[string, int]`,
+		)
+		require.Equal(
+			t,
+			[]*TypeDecl{
+				{
+					Name:    "StringCache",
+					Comment: "",
+					Type: &IndexListExpr{
+						Indices: []Type{&Ident{Name: "string"}, &Ident{Name: "int"}},
+						X:       &SelectorExpr{Package: "p2", Name: "Cache"},
+					},
+					Package:     "test",
+					PackagePath: "./test",
+				},
+			},
+			typeDeclList,
+		)
+	})
 }
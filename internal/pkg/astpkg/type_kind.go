@@ -0,0 +1,134 @@
+package astpkg
+
+type TypeKind string
+
+const (
+	TypeKindUnknown   TypeKind = "unknown"
+	TypeKindBasic     TypeKind = "basic"
+	TypeKindTypeParam TypeKind = "type_param"
+	TypeKindStruct    TypeKind = "struct"
+	TypeKindInterface TypeKind = "interface"
+	TypeKindFunc      TypeKind = "func"
+	TypeKindMap       TypeKind = "map"
+	TypeKindSlice     TypeKind = "slice"
+	TypeKindArray     TypeKind = "array"
+	TypeKindChan      TypeKind = "chan"
+	TypeKindPointer   TypeKind = "pointer"
+)
+
+// GetTypeKind returns the kind of the underlying type. Named types and aliases are
+// resolved through their underlying types, predeclared types (including error and any)
+// are basic. The kind of a named type without the resolved underlying type is unknown.
+func GetTypeKind(t Type) TypeKind {
+	switch casted := t.(type) {
+	case *Ident:
+		if casted.TypeParam {
+			return TypeKindTypeParam
+		}
+		if casted.Type == nil {
+			if isBaseType(casted) {
+				return TypeKindBasic
+			}
+			return TypeKindUnknown
+		}
+		return GetTypeKind(casted.Type)
+	case *SelectorExpr:
+		if casted.Type == nil {
+			return TypeKindUnknown
+		}
+		return GetTypeKind(casted.Type)
+	case *IndexExpr:
+		return GetTypeKind(casted.X)
+	case *IndexListExpr:
+		return GetTypeKind(casted.X)
+	case *StructType:
+		return TypeKindStruct
+	case *InterfaceType:
+		return TypeKindInterface
+	case *FuncType:
+		return TypeKindFunc
+	case *MapType:
+		return TypeKindMap
+	case *ArrayType:
+		if casted.Len != "" {
+			return TypeKindArray
+		}
+		return TypeKindSlice
+	case *EllipsisType:
+		return TypeKindSlice
+	case *ChanType:
+		return TypeKindChan
+	case *StarExpr:
+		return TypeKindPointer
+	default:
+		return TypeKindUnknown
+	}
+}
+
+// GetUnderlyingType follows identifiers, selectors and instantiations to the type literal.
+// It returns nil if the underlying type is not resolved or the type is predeclared.
+func GetUnderlyingType(t Type) Type {
+	switch casted := t.(type) {
+	case *Ident:
+		if casted.Type == nil {
+			return nil
+		}
+		return GetUnderlyingType(casted.Type)
+	case *SelectorExpr:
+		if casted.Type == nil {
+			return nil
+		}
+		return GetUnderlyingType(casted.Type)
+	case *IndexExpr:
+		return GetUnderlyingType(casted.X)
+	case *IndexListExpr:
+		return GetUnderlyingType(casted.X)
+	default:
+		return t
+	}
+}
+
+// ZeroValue returns the expression of the zero value of the type: 0, "", false, nil, T{}.
+// The zero value of an array, a type parameter or a type without the resolved underlying type is *new(T).
+func ZeroValue(t Type) string {
+	switch GetTypeKind(t) {
+	case TypeKindBasic:
+		switch getPredeclaredName(t) {
+		case "bool":
+			return "false"
+		case "string":
+			return `""`
+		case "error", "any":
+			return "nil"
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+			"float32", "float64", "complex64", "complex128",
+			"byte", "rune":
+			return "0"
+		}
+	case TypeKindStruct:
+		return t.ExprString() + "{}"
+	case TypeKindInterface, TypeKindFunc, TypeKindMap, TypeKindSlice, TypeKindChan, TypeKindPointer:
+		return "nil"
+	}
+
+	return "*new(" + t.ExprString() + ")"
+}
+
+// getPredeclaredName returns the name of the predeclared type of the basic type.
+func getPredeclaredName(t Type) string {
+	switch casted := t.(type) {
+	case *Ident:
+		if casted.Type == nil {
+			return casted.Name
+		}
+		return getPredeclaredName(casted.Type)
+	case *SelectorExpr:
+		if casted.Type == nil {
+			return ""
+		}
+		return getPredeclaredName(casted.Type)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,139 @@
+package astpkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTypeKind(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Type Type
+		Want TypeKind
+	}{
+		"predeclared": {
+			Type: &Ident{Name: "error"},
+			Want: TypeKindBasic,
+		},
+		"type param": {
+			Type: &Ident{Name: "T", TypeParam: true},
+			Want: TypeKindTypeParam,
+		},
+		"not resolved": {
+			Type: &Ident{Name: "Struct"},
+			Want: TypeKindUnknown,
+		},
+		"struct": {
+			Type: &Ident{Name: "Struct", Type: &StructType{}},
+			Want: TypeKindStruct,
+		},
+		"alias of interface": {
+			Type: &Ident{
+				Name:    "Alias",
+				IsAlias: true,
+				Type:    &SelectorExpr{Package: "p", Name: "Interface", Type: &InterfaceType{}},
+			},
+			Want: TypeKindInterface,
+		},
+		"generic instantiation": {
+			Type: &IndexExpr{Index: &Ident{Name: "string"}, X: &Ident{Name: "List", Type: &ArrayType{}}},
+			Want: TypeKindSlice,
+		},
+		"array": {
+			Type: &Ident{Name: "ID", Type: &ArrayType{Len: "16", Type: &Ident{Name: "byte"}}},
+			Want: TypeKindArray,
+		},
+		"pointer": {
+			Type: &StarExpr{Type: &Ident{Name: "Struct", Type: &StructType{}}},
+			Want: TypeKindPointer,
+		},
+		"func": {
+			Type: &FuncType{},
+			Want: TypeKindFunc,
+		},
+		"map": {
+			Type: &MapType{},
+			Want: TypeKindMap,
+		},
+		"chan": {
+			Type: &ChanType{},
+			Want: TypeKindChan,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.Want, GetTypeKind(tc.Type))
+		})
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Type Type
+		Want string
+	}{
+		"bool": {
+			Type: &Ident{Name: "bool"},
+			Want: "false",
+		},
+		"string": {
+			Type: &Ident{Name: "string"},
+			Want: `""`,
+		},
+		"number": {
+			Type: &Ident{Name: "float64"},
+			Want: "0",
+		},
+		"error": {
+			Type: &Ident{Name: "error"},
+			Want: "nil",
+		},
+		"named number": {
+			Type: &SelectorExpr{Package: "time", Name: "Duration", Type: &Ident{Name: "int64"}},
+			Want: "0",
+		},
+		"struct": {
+			Type: &SelectorExpr{Package: "p", Name: "Struct", Type: &StructType{}},
+			Want: "p.Struct{}",
+		},
+		"generic struct": {
+			Type: &IndexExpr{Index: &Ident{Name: "string"}, X: &Ident{Name: "Pair", Type: &StructType{}}},
+			Want: "Pair[string]{}",
+		},
+		"pointer": {
+			Type: &StarExpr{Type: &Ident{Name: "Struct", Type: &StructType{}}},
+			Want: "nil",
+		},
+		"slice": {
+			Type: &ArrayType{Type: &Ident{Name: "string"}},
+			Want: "nil",
+		},
+		"array": {
+			Type: &ArrayType{Len: "16", Type: &Ident{Name: "byte"}},
+			Want: "*new([16]byte)",
+		},
+		"named array": {
+			Type: &SelectorExpr{Package: "uuid", Name: "UUID", Type: &ArrayType{Len: "16", Type: &Ident{Name: "byte"}}},
+			Want: "*new(uuid.UUID)",
+		},
+		"type param": {
+			Type: &Ident{Name: "T", TypeParam: true},
+			Want: "*new(T)",
+		},
+		"not resolved": {
+			Type: &SelectorExpr{Package: "p", Name: "Type"},
+			Want: "*new(p.Type)",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.Want, ZeroValue(tc.Type))
+		})
+	}
+}
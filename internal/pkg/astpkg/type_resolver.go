@@ -0,0 +1,338 @@
+package astpkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path/filepath"
+	"strconv"
+
+	"github.com/samber/lo"
+)
+
+type trackedType struct {
+	node     PackageCarrierType
+	obj      *types.TypeName
+	instance *types.Named
+}
+
+// typeResolver links the identifiers of the syntax tree with the objects
+// of the go/types information and converts the resolved types to the package model.
+//
+// The referenced named types are expanded to their underlying types. Inside an
+// expanded type the named types of the struct fields are not expanded, the
+// recursive references are left without the underlying type.
+type typeResolver struct {
+	pkg        *types.Package
+	info       *types.Info
+	tracked    []trackedType
+	underlying map[types.Type]Type
+	inProgress map[types.Type]struct{}
+}
+
+func newTypeResolver(pkg *types.Package, info *types.Info) *typeResolver {
+	return &typeResolver{
+		pkg:        pkg,
+		info:       info,
+		tracked:    nil,
+		underlying: make(map[types.Type]Type),
+		inProgress: make(map[types.Type]struct{}),
+	}
+}
+
+// track remembers the type node to resolve it later by resolve. The nodes are not
+// resolved immediately because the package information of the syntax tree has to be
+// set before the nested types from other packages are added.
+func (r *typeResolver) track(node PackageCarrierType, ident *ast.Ident) {
+	if r == nil || r.info == nil {
+		return
+	}
+
+	obj, ok := r.info.Uses[ident].(*types.TypeName)
+	if !ok || obj.Pkg() == nil {
+		return
+	}
+
+	if _, ok := obj.Type().(*types.TypeParam); ok {
+		if casted, ok := node.(*Ident); ok {
+			casted.TypeParam = true
+		}
+		return
+	}
+
+	r.tracked = append(r.tracked, trackedType{node: node, obj: obj, instance: nil})
+}
+
+// arrayLen returns the length of the array type, it is empty for the slice. The length is evaluated
+// if the type information is available, otherwise it is the expression of the syntax tree.
+func (r *typeResolver) arrayLen(expr *ast.ArrayType) string {
+	if expr.Len == nil {
+		return ""
+	}
+
+	if r != nil && r.info != nil {
+		if array, ok := r.info.Types[expr].Type.(*types.Array); ok {
+			return strconv.FormatInt(array.Len(), 10)
+		}
+	}
+
+	return types.ExprString(expr.Len)
+}
+
+// trackInstance remembers the generic type of the instantiation expression to resolve
+// it to the underlying type with the substituted type arguments.
+func (r *typeResolver) trackInstance(node Type, expr ast.Expr) {
+	if r == nil || r.info == nil {
+		return
+	}
+
+	casted, ok := node.(PackageCarrierType)
+	if !ok {
+		return
+	}
+
+	instance, ok := r.info.Types[expr].Type.(*types.Named)
+	if !ok || instance.TypeArgs().Len() == 0 {
+		return
+	}
+
+	r.tracked = append(r.tracked, trackedType{node: casted, obj: instance.Obj(), instance: instance})
+}
+
+func (r *typeResolver) resolve() {
+	for _, item := range r.tracked {
+		var underlying Type
+		if item.instance != nil {
+			underlying = r.guard(item.instance, func() Type { return r.convert(item.instance.Underlying(), true) })
+		} else {
+			underlying = r.objUnderlying(item.obj)
+		}
+
+		switch casted := item.node.(type) {
+		case *Ident:
+			casted.Type = underlying
+			casted.IsAlias = item.obj.IsAlias()
+		case *SelectorExpr:
+			casted.Type = underlying
+			casted.IsAlias = item.obj.IsAlias()
+			if casted.PackagePath == "" {
+				casted.PackagePath = item.obj.Pkg().Path()
+			}
+		}
+	}
+
+	r.tracked = nil
+}
+
+func (r *typeResolver) objUnderlying(obj *types.TypeName) Type {
+	switch casted := obj.Type().(type) {
+	case *types.Alias:
+		return r.guard(casted, func() Type { return r.convert(casted.Rhs(), true) })
+	case *types.Named:
+		return r.guard(casted, func() Type { return r.convert(casted.Underlying(), true) })
+	default:
+		return nil
+	}
+}
+
+// guard converts the type once and breaks the recursive references.
+func (r *typeResolver) guard(t types.Type, convert func() Type) Type {
+	if res, ok := r.underlying[t]; ok {
+		return res
+	}
+
+	if _, ok := r.inProgress[t]; ok {
+		return nil
+	}
+
+	r.inProgress[t] = struct{}{}
+	res := convert()
+	delete(r.inProgress, t)
+
+	r.underlying[t] = res
+	return res
+}
+
+func (r *typeResolver) convert(t types.Type, expand bool) Type {
+	switch casted := t.(type) {
+	case *types.Basic:
+		if casted.Kind() == types.UnsafePointer {
+			return &SelectorExpr{Package: "", PackagePath: "unsafe", Name: "Pointer", Type: nil}
+		}
+		return &Ident{Package: "", PackagePath: "", Name: casted.Name(), Type: nil}
+	case *types.TypeParam:
+		return &Ident{Name: casted.Obj().Name(), TypeParam: true}
+	case *types.Named:
+		ref := r.newRef(casted.Obj())
+		if expand && casted.Obj().Pkg() != nil {
+			setRefType(ref, r.guard(casted, func() Type { return r.convert(casted.Underlying(), true) }))
+		}
+		return r.newInstance(ref, casted.TypeArgs(), expand)
+	case *types.Alias:
+		ref := r.newRef(casted.Obj())
+		if casted.Obj().Pkg() != nil {
+			setRefAlias(ref)
+			if expand {
+				setRefType(ref, r.guard(casted, func() Type { return r.convert(casted.Rhs(), true) }))
+			}
+		}
+		return r.newInstance(ref, casted.TypeArgs(), expand)
+	case *types.Pointer:
+		return &StarExpr{Type: r.convert(casted.Elem(), expand)}
+	case *types.Slice:
+		return &ArrayType{Type: r.convert(casted.Elem(), expand)}
+	case *types.Array:
+		return &ArrayType{Len: strconv.FormatInt(casted.Len(), 10), Type: r.convert(casted.Elem(), expand)}
+	case *types.Map:
+		return &MapType{
+			Key:   r.convert(casted.Key(), expand),
+			Value: r.convert(casted.Elem(), expand),
+		}
+	case *types.Chan:
+		return &ChanType{
+			Type:      r.convert(casted.Elem(), expand),
+			Direction: convertChanDir(casted.Dir()),
+		}
+	case *types.Signature:
+		return r.convertSignature(casted, expand)
+	case *types.Struct:
+		fields := make([]*Field, 0, casted.NumFields())
+		for i := range casted.NumFields() {
+			item := casted.Field(i)
+			fields = append(fields, &Field{
+				Name: lo.Ternary(item.Embedded(), "", item.Name()),
+				Type: r.convert(item.Type(), item.Embedded()),
+			})
+		}
+		return &StructType{Fields: fields}
+	case *types.Interface:
+		methods := make([]*Field, 0, casted.NumEmbeddeds()+casted.NumExplicitMethods())
+		for i := range casted.NumEmbeddeds() {
+			methods = append(methods, &Field{
+				Name: "",
+				Type: r.convert(casted.EmbeddedType(i), true),
+			})
+		}
+		for i := range casted.NumExplicitMethods() {
+			item := casted.ExplicitMethod(i)
+			signature, _ := item.Type().(*types.Signature)
+			methods = append(methods, &Field{
+				Name: item.Name(),
+				Type: r.convertSignature(signature, true),
+			})
+		}
+		return &InterfaceType{Methods: methods}
+	case *types.Union:
+		terms := make([]Type, 0, casted.Len())
+		for i := range casted.Len() {
+			term := casted.Term(i)
+			termType := r.convert(term.Type(), expand)
+			if term.Tilde() {
+				termType = &TildeExpr{Type: termType}
+			}
+			terms = append(terms, termType)
+		}
+		return &UnionExpr{Terms: terms}
+	default:
+		panic(fmt.Sprintf("unknown type: %+[1]v(%[1]T)", casted))
+	}
+}
+
+func (r *typeResolver) convertSignature(t *types.Signature, expand bool) *FuncType {
+	convertTuple := func(tuple *types.Tuple, variadic bool) []*Field {
+		list := make([]*Field, 0, tuple.Len())
+		for i := range tuple.Len() {
+			item := tuple.At(i)
+
+			var itemType Type
+			if slice, ok := item.Type().(*types.Slice); ok && variadic && i == tuple.Len()-1 {
+				itemType = &EllipsisType{Type: r.convert(slice.Elem(), expand)}
+			} else {
+				itemType = r.convert(item.Type(), expand)
+			}
+
+			list = append(list, &Field{Name: item.Name(), Type: itemType})
+		}
+		return list
+	}
+
+	return &FuncType{
+		Params:  convertTuple(t.Params(), t.Variadic()),
+		Results: convertTuple(t.Results(), false),
+	}
+}
+
+// newRef returns the reference to the named type: the types of the parsed package
+// and predeclared types are identifiers, the types of other packages are selectors.
+func (r *typeResolver) newRef(obj *types.TypeName) PackageCarrierType {
+	if obj.Pkg() == nil || obj.Pkg() == r.pkg {
+		return &Ident{Package: "", PackagePath: "", Name: obj.Name(), Type: nil}
+	}
+
+	imp := newImportFromTypesPackage(obj.Pkg())
+
+	return &SelectorExpr{
+		Package:     imp.Alias,
+		PackagePath: imp.Path,
+		Name:        obj.Name(),
+		Type:        nil,
+	}
+}
+
+func (r *typeResolver) newInstance(ref PackageCarrierType, args *types.TypeList, expand bool) Type {
+	refType, _ := ref.(Type)
+	if args.Len() == 0 {
+		return refType
+	}
+
+	indices := make([]Type, 0, args.Len())
+	for i := range args.Len() {
+		indices = append(indices, r.convert(args.At(i), expand))
+	}
+
+	if len(indices) == 1 {
+		return &IndexExpr{Index: indices[0], X: refType}
+	}
+
+	return &IndexListExpr{Indices: indices, X: refType}
+}
+
+func setRefType(ref PackageCarrierType, t Type) {
+	switch casted := ref.(type) {
+	case *Ident:
+		casted.Type = t
+	case *SelectorExpr:
+		casted.Type = t
+	}
+}
+
+func setRefAlias(ref PackageCarrierType) {
+	switch casted := ref.(type) {
+	case *Ident:
+		casted.IsAlias = true
+	case *SelectorExpr:
+		casted.IsAlias = true
+	}
+}
+
+// newImportFromTypesPackage returns the import in the same form as ImportList.Get:
+// the alias is empty if it is equal to the last element of the path.
+func newImportFromTypesPackage(pkg *types.Package) Import {
+	alias := pkg.Name()
+	if alias == filepath.Base(pkg.Path()) {
+		alias = ""
+	}
+
+	return NewImport(alias, pkg.Path())
+}
+
+func convertChanDir(dir types.ChanDir) ast.ChanDir {
+	switch dir {
+	case types.SendOnly:
+		return ast.SEND
+	case types.RecvOnly:
+		return ast.RECV
+	default:
+		return ast.SEND | ast.RECV
+	}
+}
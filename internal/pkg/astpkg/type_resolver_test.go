@@ -0,0 +1,152 @@
+package astpkg
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeResolver(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := ParsePackage("github.com/khevse/codegen/tests/mainpkg")
+	require.NoError(t, err)
+
+	getMethodResult := func(t *testing.T, typeName, methodName string) Type {
+		typeDecl, ok := pkg.TypeDeclList.GetByName(typeName)
+		require.True(t, ok)
+
+		castedType, ok := typeDecl.Type.(*InterfaceType)
+		require.True(t, ok)
+
+		method, ok := lo.Find(castedType.Methods, func(item *Field) bool {
+			return item.Name == methodName
+		})
+		require.True(t, ok)
+
+		return method.Type.(*FuncType).Results[0].Type
+	}
+
+	t.Run("alias of type from other package", func(t *testing.T) {
+		res := getMethodResult(t, "IResolver", "Interface")
+		ident, ok := res.(*Ident)
+		require.True(t, ok)
+		require.True(t, ident.IsAlias)
+		require.Equal(t, "ChildInterface", ident.ExprString())
+
+		selector, ok := ident.Type.(*SelectorExpr)
+		require.True(t, ok)
+		require.False(t, selector.IsAlias)
+		require.Equal(t, "github.com/khevse/codegen/tests/mainpkg/childpkg", selector.PackagePath)
+		require.Equal(t, "Interface", selector.Name)
+		require.Equal(t, TypeKindInterface, GetTypeKind(res))
+		require.Equal(
+			t,
+			"interface{_ SelectorExpr(Stringer);OtherMethod FuncType(func() (_ any))}",
+			GetUnderlyingType(res).ExprString(),
+		)
+	})
+
+	t.Run("recursive interface from other package", func(t *testing.T) {
+		res := getMethodResult(t, "IResolver", "Tree")
+		require.Equal(t, TypeKindInterface, GetTypeKind(res))
+
+		tree, ok := GetUnderlyingType(res).(*InterfaceType)
+		require.True(t, ok)
+		require.Equal(
+			t,
+			[]string{"Children func() (_ []Tree)", "Parent func() (_ Tree)"},
+			lo.Map(tree.Methods, func(item *Field, _ int) string {
+				return item.Name + " " + item.Type.ExprString()
+			}),
+		)
+
+		parent := tree.Methods[1].Type.(*FuncType).Results[0].Type
+		require.Equal(
+			t,
+			&SelectorExpr{
+				Package:     "",
+				PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
+				Name:        "Tree",
+				Type:        nil,
+			},
+			parent,
+		)
+	})
+
+	t.Run("named basic type", func(t *testing.T) {
+		res := getMethodResult(t, "IResolver", "ID")
+		require.Equal(t, &Ident{Name: "ID", Type: &Ident{Name: "string"}}, res)
+		require.Equal(t, TypeKindBasic, GetTypeKind(res))
+	})
+
+	t.Run("generic instantiation", func(t *testing.T) {
+		res := getMethodResult(t, "IResolver", "Cache")
+		require.Equal(t, TypeKindPointer, GetTypeKind(res))
+		require.Equal(t, "*Cache[ID, Struct]", res.ExprString())
+
+		instance, ok := res.(*StarExpr).Type.(*IndexListExpr)
+		require.True(t, ok)
+		require.Equal(t, TypeKindStruct, GetTypeKind(instance))
+		require.Equal(
+			t,
+			&StructType{
+				Fields: []*Field{
+					{
+						Name: "items",
+						Type: &MapType{
+							Key: &Ident{Name: "ID"},
+							Value: &SelectorExpr{
+								PackagePath: "github.com/khevse/codegen/tests/mainpkg/childpkg",
+								Name:        "Struct",
+							},
+						},
+					},
+				},
+			},
+			GetUnderlyingType(instance),
+		)
+	})
+
+	t.Run("type params", func(t *testing.T) {
+		decl, ok := lo.Find(pkg.FuncDeclList, func(item *FuncDecl) bool {
+			return item.Receiver == "Cache" && item.Name == "Set"
+		})
+		require.True(t, ok)
+		require.Equal(
+			t,
+			[]*Field{
+				{Name: "key", Type: &Ident{Name: "K", TypeParam: true}},
+				{Name: "val", Type: &Ident{Name: "V", TypeParam: true}},
+			},
+			decl.Params,
+		)
+		require.Equal(t, TypeKindTypeParam, GetTypeKind(decl.Params[0].Type))
+	})
+}
+
+func TestTypeResolverPackagePath(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := ParsePackage("github.com/khevse/codegen/tests/mainpkg")
+	require.NoError(t, err)
+	require.NoError(t, SetPackagePathForAllDecl(pkg))
+
+	decl, ok := lo.Find(pkg.FuncDeclList, func(item *FuncDecl) bool {
+		return item.Receiver == "Cache" && item.Name == "Get"
+	})
+	require.True(t, ok)
+	require.Equal(t, "key K", decl.Params[0].Name+" "+decl.Params[0].Type.ExprString())
+
+	typeDecl, ok := pkg.TypeDeclList.GetByName("IResolver")
+	require.True(t, ok)
+	require.Equal(
+		t,
+		"interface{Interface FuncType(func() (_ mainpkg.ChildInterface));"+
+			"Tree FuncType(func() (_ Tree));"+
+			"ID FuncType(func() (_ mainpkg.ID));"+
+			"Cache FuncType(func() (_ *mainpkg.Cache[mainpkg.ID, Struct]))}",
+		typeDecl.Type.ExprString(),
+	)
+}
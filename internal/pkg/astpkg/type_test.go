@@ -32,7 +32,7 @@ func TestType(t *testing.T) {
 		require.Empty(t, decl.GetSignatureImports())
 	})
 
-	t.Run("Ident with type", func(t *testing.T) {
+	t.Run("Ident without type information", func(t *testing.T) {
 		decl := newFuncDeclForTest(
 			t,
 			`package p; type Struct struct{}; func test(val Struct) Struct { return val };`,
@@ -48,7 +48,7 @@ func TestType(t *testing.T) {
 						Name: "val",
 						Type: &Ident{
 							Name: "Struct",
-							Type: &StructType{Fields: []*Field{}},
+							Type: nil,
 						},
 					},
 				},
@@ -57,7 +57,7 @@ func TestType(t *testing.T) {
 						Name: "",
 						Type: &Ident{
 							Name: "Struct",
-							Type: &StructType{Fields: []*Field{}},
+							Type: nil,
 						},
 					},
 				},
@@ -131,6 +131,21 @@ func TestType(t *testing.T) {
 		require.Empty(t, decl.GetSignatureImports())
 	})
 
+	t.Run("ArrayType with length", func(t *testing.T) {
+		decl := newFuncDeclForTest(
+			t,
+			`package p; func test(val [16]byte) [16]byte { return val };`,
+		)
+		require.Equal(
+			t,
+			&ArrayType{Len: "16", Type: &Ident{Name: "byte"}},
+			decl.Results[0].Type,
+		)
+		require.Equal(t, "val ArrayType([16]byte)", decl.Params[0].String())
+		require.Equal(t, TypeKindArray, GetTypeKind(decl.Results[0].Type))
+		require.Equal(t, "*new([16]byte)", ZeroValue(decl.Results[0].Type))
+	})
+
 	t.Run("MapType", func(t *testing.T) {
 		decl := newFuncDeclForTest(
 			t,
@@ -456,6 +471,35 @@ func TestType(t *testing.T) {
 		require.Equal(t, "_ StarExpr(*p2.Item[p2.ID])", decl.Results[0].String())
 		require.Equal(t, ImportList{{Alias: "p2", Path: ""}}, decl.GetSignatureImports())
 	})
+	t.Run("IndexListExpr", func(t *testing.T) {
+		decl := newFuncDeclForTest(
+			t,
+			`package p;
+			import p2 "./example"
+			func test(val p2.Pair[string, p2.ID]) p2.Pair[string, p2.ID] { return val };`,
+		)
+		want := &IndexListExpr{
+			Indices: []Type{
+				&Ident{Name: "string"},
+				&SelectorExpr{Package: "p2", PackagePath: "", Name: "ID", Type: nil},
+			},
+			X: &SelectorExpr{Package: "p2", PackagePath: "", Name: "Pair", Type: nil},
+		}
+		require.Equal(
+			t,
+			&FuncDecl{
+				Receiver: "",
+				Name:     "test",
+				Comment:  "",
+				Params:   []*Field{{Name: "val", Type: want}},
+				Results:  []*Field{{Name: "", Type: want}},
+			},
+			decl,
+		)
+		require.Equal(t, "val IndexListExpr(p2.Pair[string, p2.ID])", decl.Params[0].String())
+		require.Equal(t, "_ IndexListExpr(p2.Pair[string, p2.ID])", decl.Results[0].String())
+		require.Equal(t, ImportList{{Alias: "p2", Path: ""}}, lo.Uniq(decl.GetSignatureImports()))
+	})
 }
 
 func TestSetPackageInformation(t *testing.T) {
@@ -509,7 +553,7 @@ func TestSetPackageInformation(t *testing.T) {
 		require.Equal(t, "_ Ident(string)", decl.Results[0].String())
 	})
 
-	t.Run("Ident with type", func(t *testing.T) {
+	t.Run("Ident without type information", func(t *testing.T) {
 		decl := newFuncDecl(
 			t,
 			`package p; type Struct struct{}; func test(val Struct) Struct { return val };`,
@@ -527,7 +571,7 @@ func TestSetPackageInformation(t *testing.T) {
 						Type: &Ident{
 							Package: "",
 							Name:    "Struct",
-							Type:    &StructType{Fields: []*Field{}},
+							Type:    nil,
 						},
 					},
 				},
@@ -536,7 +580,7 @@ func TestSetPackageInformation(t *testing.T) {
 						Name: "",
 						Type: &Ident{
 							Name: "Struct",
-							Type: &StructType{Fields: []*Field{}},
+							Type: nil,
 						},
 					},
 				},
@@ -586,3 +630,53 @@ func TestSetPackageInformation(t *testing.T) {
 		)
 	})
 }
+
+func TestRenameTypeParams(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Code    string
+		Names   map[string]string
+		Want    []string
+		WantErr string
+	}{
+		"rename": {
+			Code:  `package p; func (c *Cache[A, B]) Get(key A, val map[A]B) (p2.Pair[A, B], T) {};`,
+			Names: map[string]string{"A": "K", "B": "V"},
+			Want:  []string{"key K", "val map[K]V", "_ p2.Pair[K, V]", "_ T"},
+		},
+		"swap": {
+			Code:  `package p; func (c *Cache[B, A]) Get(key B, val map[B]A) (p2.Pair[B, A], T) {};`,
+			Names: map[string]string{"A": "B", "B": "A"},
+			Want:  []string{"key A", "val map[A]B", "_ p2.Pair[A, B]", "_ T"},
+		},
+		"collision": {
+			Code:    `package p; func (c *Cache[A, B]) Get(key A, val map[A]B) (p2.Pair[A, B], K) {};`,
+			Names:   map[string]string{"A": "K", "B": "V"},
+			WantErr: "inspect results: inspect field(name=): *astpkg.Ident(Ident(K)): rename type param A: the name K is already used",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			decl := newFuncDeclForTest(t, tc.Code)
+
+			err := InspectFuncDeclFields(decl, func(f *Field) error {
+				return RenameTypeParams(f.Type, tc.Names)
+			})
+			if tc.WantErr != "" {
+				require.EqualError(t, err, tc.WantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				tc.Want,
+				lo.Map(append(decl.Params, decl.Results...), func(item *Field, _ int) string {
+					return lo.Ternary(item.Name == "", "_", item.Name) + " " + item.Type.ExprString()
+				}),
+			)
+		})
+	}
+}
@@ -6,3 +6,8 @@ type Command interface {
 	InitFlags(flagSetter FlagSetter) error
 	Execute() error
 }
+
+// ArgsSetter is implemented by the commands accepting the positional arguments.
+type ArgsSetter interface {
+	SetArgs(args []string)
+}
@@ -0,0 +1,92 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/spf13/pflag"
+)
+
+// FlagSet is the FlagSetter of the commands executed without the command line:
+// the flags values are set by name and the required flags are checked by Validate.
+type FlagSet struct {
+	flags    *pflag.FlagSet
+	required []string
+}
+
+func NewFlagSet(name string) *FlagSet {
+	flags := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	// the errors are returned, the usage is not printed
+	flags.SetOutput(io.Discard)
+
+	return &FlagSet{
+		flags: flags,
+	}
+}
+
+func (s *FlagSet) Flags() *pflag.FlagSet {
+	return s.flags
+}
+
+func (s *FlagSet) MarkFlagRequired(name string) error {
+	if s.flags.Lookup(name) == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+
+	s.required = append(s.required, name)
+	return nil
+}
+
+// Parse parses the command line flags, the positional arguments are not allowed.
+func (s *FlagSet) Parse(args []string) error {
+	positional, err := s.ParseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 0 {
+		return fmt.Errorf("unexpected arguments: %v", positional)
+	}
+
+	return nil
+}
+
+// ParseArgs parses the command line flags and returns the positional arguments.
+func (s *FlagSet) ParseArgs(args []string) ([]string, error) {
+	if err := s.flags.Parse(args); err != nil {
+		return nil, fmt.Errorf("parse flags: %w", err)
+	}
+
+	return s.flags.Args(), nil
+}
+
+// Set sets the value of the flag.
+func (s *FlagSet) Set(name, value string) error {
+	if s.flags.Lookup(name) == nil {
+		return fmt.Errorf("unknown flag: %s", name)
+	}
+
+	if err := s.flags.Set(name, value); err != nil {
+		return fmt.Errorf("set flag(%s): %w", name, err)
+	}
+
+	return nil
+}
+
+// Validate checks that all required flags are set.
+func (s *FlagSet) Validate() error {
+	missing := make([]string, 0)
+	for _, name := range s.required {
+		if !s.flags.Changed(name) {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) != 0 {
+		slices.Sort(missing)
+		return fmt.Errorf("required flags are not set: %v", missing)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/samber/lo"
+)
+
+const (
+	// CheckFlag is the flag of the check mode of the commands.
+	CheckFlag = "check"
+	// DryRunFlag is the flag of the dry run mode of the commands.
+	DryRunFlag = "dry-run"
+	// OutputFlag is the flag of the output of the generated code of the commands.
+	OutputFlag = "output"
+)
+
+// PackageCacheSetter is implemented by the commands which can share the parsed packages.
+type PackageCacheSetter interface {
+	SetPackageCache(cache *astpkg.PackageCache)
+}
+
+// Find returns the command by the name or the short name.
+func Find(commands []Command, name string) (Command, bool) {
+	return lo.Find(commands, func(cmd Command) bool {
+		return cmd.Name() == name || cmd.ShortName() == name
+	})
+}
+
+type RunJobParams struct {
+	Command Command
+	// SetFlags sets the flags values of the job
+	SetFlags func(flagSet *FlagSet) error
+	// Check enables the check mode of the command
+	Check bool
+	// DryRun enables the dry run mode of the command
+	DryRun bool
+	// Output sets the output of the generated code of the command
+	Output string
+	Cache  *astpkg.PackageCache
+}
+
+// RunJob executes the command with the flags of the job. The flags are initialized again,
+// so the values of the previous job are reset.
+func RunJob(params RunJobParams) error {
+	cmd := params.Command
+
+	flagSet := NewFlagSet(cmd.Name())
+	if err := cmd.InitFlags(flagSet); err != nil {
+		return fmt.Errorf("init flags: %w", err)
+	}
+
+	if err := params.SetFlags(flagSet); err != nil {
+		return err
+	}
+
+	modes := []struct {
+		name    string
+		flag    string
+		value   string
+		enabled bool
+	}{
+		{name: "check", flag: CheckFlag, value: "true", enabled: params.Check},
+		{name: "dry run", flag: DryRunFlag, value: "true", enabled: params.DryRun},
+		{name: "output", flag: OutputFlag, value: params.Output, enabled: params.Output != ""},
+	}
+	for _, mode := range modes {
+		if !mode.enabled {
+			continue
+		}
+
+		if flagSet.Flags().Lookup(mode.flag) == nil {
+			return fmt.Errorf("command does not support the %s mode", mode.name)
+		}
+
+		if err := flagSet.Set(mode.flag, mode.value); err != nil {
+			return err
+		}
+	}
+
+	if err := flagSet.Validate(); err != nil {
+		return err
+	}
+
+	if setter, ok := cmd.(PackageCacheSetter); ok {
+		setter.SetPackageCache(params.Cache)
+		defer setter.SetPackageCache(nil)
+	}
+
+	if err := cmd.Execute(); err != nil {
+		return fmt.Errorf("execute(%s): %w", cmd.Name(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package diffpkg
+
+// diffLines returns the shortest edit script of the lines by the Myers algorithm.
+func diffLines(a, b []string) []op {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+
+	// trace contains the furthest reaching points of the previous step for each step
+	trace := make([][]int, 0)
+
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+
+	return nil
+}
+
+func backtrack(a, b []string, trace [][]int) []op {
+	x, y := len(a), len(b)
+	res := make([]op, 0, len(a)+len(b))
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		// the snapshot of the step d starts from the diagonal -d-1
+		v := func(k int) int {
+			return trace[d][k+d+1]
+		}
+
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v(k-1) < v(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := v(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			res = append(res, op{Kind: opEqual, Line: a[x], OldPos: x, NewPos: y})
+		}
+
+		if d == 0 {
+			break
+		}
+
+		if x == prevX {
+			y--
+			res = append(res, op{Kind: opInsert, Line: b[y], OldPos: x, NewPos: y})
+		} else {
+			x--
+			res = append(res, op{Kind: opDelete, Line: a[x], OldPos: x, NewPos: y})
+		}
+	}
+
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+
+	return res
+}
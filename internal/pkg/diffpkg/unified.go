@@ -0,0 +1,116 @@
+package diffpkg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of the unchanged lines around the changes.
+const contextLines = 3
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type op struct {
+	Kind opKind
+	Line string
+	// OldPos and NewPos are the positions of the line in the old and new texts
+	OldPos int
+	NewPos int
+}
+
+// Unified returns the unified diff of the texts, the result is empty if the texts are equal.
+func Unified(oldName, newName string, oldText, newText []byte) string {
+	if string(oldText) == string(newText) {
+		return ""
+	}
+
+	ops := diffLines(splitLines(string(oldText)), splitLines(string(newText)))
+
+	buf := strings.Builder{}
+	fmt.Fprintf(&buf, "--- %s\n", oldName)
+	fmt.Fprintf(&buf, "+++ %s\n", newName)
+
+	for i := 0; i < len(ops); {
+		for i < len(ops) && ops[i].Kind == opEqual {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+
+		start := max(i-contextLines, 0)
+		end := i
+		for {
+			for end < len(ops) && ops[end].Kind != opEqual {
+				end++
+			}
+
+			next := end
+			for next < len(ops) && ops[next].Kind == opEqual {
+				next++
+			}
+
+			if next < len(ops) && next-end <= 2*contextLines {
+				end = next
+				continue
+			}
+
+			end = min(end+contextLines, len(ops))
+			break
+		}
+
+		writeHunk(&buf, ops[start:end])
+		i = end
+	}
+
+	return buf.String()
+}
+
+func writeHunk(buf *strings.Builder, ops []op) {
+	oldCount, newCount := 0, 0
+	for _, item := range ops {
+		if item.Kind != opInsert {
+			oldCount++
+		}
+		if item.Kind != opDelete {
+			newCount++
+		}
+	}
+
+	fmt.Fprintf(buf, "@@ -%s +%s @@\n", hunkRange(ops[0].OldPos, oldCount), hunkRange(ops[0].NewPos, newCount))
+
+	for _, item := range ops {
+		buf.WriteByte(byte(item.Kind))
+		buf.WriteString(item.Line)
+		if !strings.HasSuffix(item.Line, "\n") {
+			buf.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+func hunkRange(pos, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", pos)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", pos+1)
+	}
+
+	return fmt.Sprintf("%d,%d", pos+1, count)
+}
+
+// splitLines splits the text to the lines with the line endings.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
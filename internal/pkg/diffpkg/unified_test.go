@@ -0,0 +1,82 @@
+package diffpkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnified(t *testing.T) {
+	t.Parallel()
+
+	t.Run("equal", func(t *testing.T) {
+		require.Empty(t, Unified("a", "b", []byte("1\n2\n"), []byte("1\n2\n")))
+	})
+
+	t.Run("new file", func(t *testing.T) {
+		require.Equal(t,
+			"--- a\n+++ b\n@@ -0,0 +1,2 @@\n+1\n+2\n",
+			Unified("a", "b", nil, []byte("1\n2\n")),
+		)
+	})
+
+	t.Run("removed file", func(t *testing.T) {
+		require.Equal(t,
+			"--- a\n+++ b\n@@ -1 +0,0 @@\n-1\n",
+			Unified("a", "b", []byte("1\n"), nil),
+		)
+	})
+
+	t.Run("changes with context", func(t *testing.T) {
+		oldText := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n"
+		newText := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n11\n12\n13\n14\n16\n17\n"
+
+		require.Equal(t,
+			`--- a
++++ b
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+@@ -12,5 +12,5 @@
+ 12
+ 13
+ 14
+-15
+ 16
++17
+`,
+			Unified("a", "b", []byte(oldText), []byte(newText)),
+		)
+	})
+
+	t.Run("merged hunks", func(t *testing.T) {
+		require.Equal(t,
+			`--- a
++++ b
+@@ -1,6 +1,6 @@
+-1
++one
+ 2
+ 3
+ 4
+ 5
+-6
++six
+`,
+			Unified("a", "b", []byte("1\n2\n3\n4\n5\n6\n"), []byte("one\n2\n3\n4\n5\nsix\n")),
+		)
+	})
+
+	t.Run("no newline at end of file", func(t *testing.T) {
+		require.Equal(t,
+			"--- a\n+++ b\n@@ -1 +1 @@\n-1\n\\ No newline at end of file\n+1\n",
+			Unified("a", "b", []byte("1"), []byte("1\n")),
+		)
+	})
+}
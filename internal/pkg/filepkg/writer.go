@@ -0,0 +1,161 @@
+package filepkg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/khevse/codegen/internal/pkg/diffpkg"
+)
+
+// fileMode is the permissions of the generated files.
+const fileMode os.FileMode = 0o644
+
+// StdoutOutput is the value of the output flag which prints the generated code to stdout.
+const StdoutOutput = "-"
+
+// ErrOutdated is returned in the check mode if the files on disk differ from the generated code.
+var ErrOutdated = errors.New("generated files are out of date")
+
+type WriterParams struct {
+	// Check enables the check mode: the files are not written, the differences are written to the Output
+	Check bool
+	// DryRun enables the dry run mode: the files are not written, the changed files are listed in the Output
+	DryRun bool
+	// OutputFile is the destination of the generated code instead of the files,
+	// only StdoutOutput is supported: the generated code is written to the Output
+	OutputFile string
+	Output     io.Writer
+}
+
+// Writer writes the generated files.
+type Writer struct {
+	params   WriterParams
+	outdated []string
+}
+
+func NewWriter(params WriterParams) (*Writer, error) {
+	if params.OutputFile != "" && params.OutputFile != StdoutOutput {
+		return nil, fmt.Errorf("unsupported output %q, only %q is supported", params.OutputFile, StdoutOutput)
+	}
+
+	modes := 0
+	for _, enabled := range []bool{params.Check, params.DryRun, params.OutputFile != ""} {
+		if enabled {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return nil, errors.New("check, dry run and output modes can not be combined")
+	}
+
+	return &Writer{
+		params: params,
+	}, nil
+}
+
+// WriteFile writes the data to the file, in the check mode compares the data with the file,
+// in the dry run mode lists the file if it is new or changed.
+func (w *Writer) WriteFile(filePath string, data []byte) error {
+	if w.params.OutputFile == StdoutOutput {
+		if _, err := w.params.Output.Write(data); err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
+		return nil
+	}
+
+	current, err := os.ReadFile(filePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read file(%s): %w", filePath, err)
+	}
+	exists := err == nil
+
+	if w.params.Check {
+		return w.checkFile(filePath, current, data)
+	}
+
+	// the file is not touched to keep the modification time for the build caches
+	if exists && bytes.Equal(current, data) {
+		return nil
+	}
+
+	if w.params.DryRun {
+		status := "changed"
+		if !exists {
+			status = "new"
+		}
+
+		if _, err := fmt.Fprintf(w.params.Output, "%s (%s)\n", filePath, status); err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
+		return nil
+	}
+
+	if err := writeFileAtomic(filePath, data); err != nil {
+		return fmt.Errorf("write file(%s): %w", filePath, err)
+	}
+
+	return nil
+}
+
+// writeFileAtomic writes the data to the temporary file in the same dir and renames it,
+// so the file is never left partially written.
+func writeFileAtomic(filePath string, data []byte) (resErr error) {
+	f, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer func() {
+		if resErr != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("write temporary file: %w", err)
+	}
+	if err := f.Chmod(fileMode); err != nil {
+		return fmt.Errorf("change temporary file mode: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("sync temporary file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close temporary file: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), filePath); err != nil {
+		return fmt.Errorf("rename temporary file: %w", err)
+	}
+
+	return nil
+}
+
+func (w *Writer) checkFile(filePath string, current, data []byte) error {
+	diff := diffpkg.Unified(filePath, filePath+" (generated)", current, data)
+	if diff == "" {
+		return nil
+	}
+
+	w.outdated = append(w.outdated, filePath)
+
+	if _, err := io.WriteString(w.params.Output, diff); err != nil {
+		return fmt.Errorf("write diff: %w", err)
+	}
+
+	return nil
+}
+
+// Err returns ErrOutdated if the check mode found the outdated files.
+func (w *Writer) Err() error {
+	if len(w.outdated) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrOutdated, strings.Join(w.outdated, ", "))
+}
@@ -0,0 +1,195 @@
+package filepkg
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("write", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "file.go")
+		require.NoError(t, os.WriteFile(filePath, []byte("package old\n\nvar a = 1\n"), 0o644))
+
+		w := newTestWriter(t, WriterParams{})
+		require.NoError(t, w.WriteFile(filePath, []byte("package new\n")))
+		require.NoError(t, w.Err())
+
+		data, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		require.Equal(t, "package new\n", string(data))
+
+		info, err := os.Stat(filePath)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+
+		// the temporary files are removed
+		entries, err := os.ReadDir(filepath.Dir(filePath))
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	})
+
+	t.Run("write new file", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "file.go")
+
+		w := newTestWriter(t, WriterParams{})
+		require.NoError(t, w.WriteFile(filePath, []byte("package new\n")))
+
+		info, err := os.Stat(filePath)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+	})
+
+	t.Run("unchanged file is not touched", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "file.go")
+		require.NoError(t, os.WriteFile(filePath, []byte("package pkg\n"), 0o600))
+
+		modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		require.NoError(t, os.Chtimes(filePath, modTime, modTime))
+
+		w := newTestWriter(t, WriterParams{})
+		require.NoError(t, w.WriteFile(filePath, []byte("package pkg\n")))
+
+		info, err := os.Stat(filePath)
+		require.NoError(t, err)
+		require.True(t, modTime.Equal(info.ModTime()))
+		require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("target dir does not exist", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "unknown", "file.go")
+
+		w := newTestWriter(t, WriterParams{})
+		require.ErrorContains(t, w.WriteFile(filePath, []byte("package pkg\n")), "create temporary file")
+	})
+
+	t.Run("check up to date", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "file.go")
+		require.NoError(t, os.WriteFile(filePath, []byte("package pkg\n"), 0o644))
+
+		diff := bytes.NewBuffer(nil)
+		w := newTestWriter(t, WriterParams{Check: true, Output: diff})
+		require.NoError(t, w.WriteFile(filePath, []byte("package pkg\n")))
+		require.NoError(t, w.Err())
+		require.Empty(t, diff.String())
+	})
+
+	t.Run("check outdated", func(t *testing.T) {
+		dir := t.TempDir()
+		filePath := filepath.Join(dir, "file.go")
+		newFilePath := filepath.Join(dir, "new.go")
+		require.NoError(t, os.WriteFile(filePath, []byte("package pkg\n\nvar a = 1\n"), 0o644))
+
+		diff := bytes.NewBuffer(nil)
+		w := newTestWriter(t, WriterParams{Check: true, Output: diff})
+		require.NoError(t, w.WriteFile(filePath, []byte("package pkg\n\nvar a = 2\n")))
+		require.NoError(t, w.WriteFile(newFilePath, []byte("package pkg\n")))
+
+		err := w.Err()
+		require.ErrorIs(t, err, ErrOutdated)
+		require.EqualError(t, err, "generated files are out of date: "+filePath+", "+newFilePath)
+		require.Equal(t,
+			"--- "+filePath+"\n+++ "+filePath+" (generated)\n@@ -1,3 +1,3 @@\n package pkg\n \n-var a = 1\n+var a = 2\n"+
+				"--- "+newFilePath+"\n+++ "+newFilePath+" (generated)\n@@ -0,0 +1 @@\n+package pkg\n",
+			diff.String(),
+		)
+
+		// the files are not changed
+		data, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		require.Equal(t, "package pkg\n\nvar a = 1\n", string(data))
+		require.NoFileExists(t, newFilePath)
+	})
+}
+
+func TestWriterDryRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "file.go")
+	unchangedFilePath := filepath.Join(dir, "unchanged.go")
+	newFilePath := filepath.Join(dir, "new.go")
+	require.NoError(t, os.WriteFile(filePath, []byte("package pkg\n\nvar a = 1\n"), 0o644))
+	require.NoError(t, os.WriteFile(unchangedFilePath, []byte("package pkg\n"), 0o644))
+
+	output := bytes.NewBuffer(nil)
+	w := newTestWriter(t, WriterParams{DryRun: true, Output: output})
+	require.NoError(t, w.WriteFile(filePath, []byte("package pkg\n\nvar a = 2\n")))
+	require.NoError(t, w.WriteFile(unchangedFilePath, []byte("package pkg\n")))
+	require.NoError(t, w.WriteFile(newFilePath, []byte("package pkg\n")))
+	require.NoError(t, w.Err())
+
+	require.Equal(t, filePath+" (changed)\n"+newFilePath+" (new)\n", output.String())
+
+	// the files are not changed
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	require.Equal(t, "package pkg\n\nvar a = 1\n", string(data))
+	require.NoFileExists(t, newFilePath)
+}
+
+func TestWriterStdoutOutput(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "file.go")
+	require.NoError(t, os.WriteFile(filePath, []byte("package pkg\n"), 0o644))
+
+	output := bytes.NewBuffer(nil)
+	w := newTestWriter(t, WriterParams{OutputFile: StdoutOutput, Output: output})
+	require.NoError(t, w.WriteFile(filePath, []byte("package first\n")))
+	require.NoError(t, w.WriteFile(filepath.Join(dir, "new.go"), []byte("package second\n")))
+	require.NoError(t, w.Err())
+
+	require.Equal(t, "package first\npackage second\n", output.String())
+
+	// the files are not changed
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	require.Equal(t, "package pkg\n", string(data))
+	require.NoFileExists(t, filepath.Join(dir, "new.go"))
+}
+
+func TestNewWriterInvalidParams(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Params  WriterParams
+		WantErr string
+	}{
+		"unsupported output": {
+			Params:  WriterParams{OutputFile: "file.go"},
+			WantErr: `unsupported output "file.go", only "-" is supported`,
+		},
+		"check and dry run": {
+			Params:  WriterParams{Check: true, DryRun: true},
+			WantErr: "check, dry run and output modes can not be combined",
+		},
+		"dry run and output": {
+			Params:  WriterParams{DryRun: true, OutputFile: StdoutOutput},
+			WantErr: "check, dry run and output modes can not be combined",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewWriter(tc.Params)
+			require.EqualError(t, err, tc.WantErr)
+		})
+	}
+}
+
+func newTestWriter(t *testing.T, params WriterParams) *Writer {
+	t.Helper()
+
+	w, err := NewWriter(params)
+	require.NoError(t, err)
+
+	return w
+}
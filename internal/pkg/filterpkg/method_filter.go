@@ -0,0 +1,180 @@
+package filterpkg
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+)
+
+// IgnoreDirective is the directive of the method doc comment excluding the method: //codegen:ignore.
+const IgnoreDirective = "ignore"
+
+// Params are the include and exclude rules of the methods.
+// The name rule is <MethodName> for the methods of all types or <TypeName>.<MethodName>,
+// the regular expression matches <TypeName>.<MethodName>.
+type Params struct {
+	Include      []string
+	Exclude      []string
+	IncludeRegex []string
+	ExcludeRegex []string
+}
+
+type rule struct {
+	kind       string
+	value      string
+	typeName   string
+	methodName string
+	regex      *regexp.Regexp
+	matched    bool
+	// skipReason is the reason of the skipped method matching the rule.
+	skipReason string
+}
+
+func newRuleList(kind string, values []string) ([]*rule, error) {
+	list := make([]*rule, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+
+		item := &rule{kind: kind, value: value, methodName: value}
+		if typeName, methodName, ok := strings.Cut(value, "."); ok {
+			item.typeName = typeName
+			item.methodName = methodName
+		}
+		if item.methodName == "" || (item.typeName == "" && strings.Contains(value, ".")) {
+			return nil, fmt.Errorf("invalid %s rule: %s", kind, value)
+		}
+
+		list = append(list, item)
+	}
+
+	return list, nil
+}
+
+func newRegexRuleList(kind string, values []string) ([]*rule, error) {
+	list := make([]*rule, 0, len(values))
+	for _, value := range values {
+		regex, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("compile %s rule(%s): %w", kind, value, err)
+		}
+
+		list = append(list, &rule{kind: kind, value: value, regex: regex})
+	}
+
+	return list, nil
+}
+
+// restricts reports whether the rule is applied to the methods of the type.
+func (r *rule) restricts(typeName string) bool {
+	return r.regex != nil || r.typeName == "" || r.typeName == typeName
+}
+
+func (r *rule) match(typeName, methodName string) bool {
+	if r.regex != nil {
+		return r.regex.MatchString(typeName + "." + methodName)
+	}
+
+	return r.restricts(typeName) && r.methodName == methodName
+}
+
+// MethodFilter selects the methods of the generated code by the include and exclude rules.
+// The exclude rules and the ignore directive have the priority over the include rules,
+// the include rules restrict only the types they are applied to.
+// The nil filter matches all methods without the ignore directive.
+type MethodFilter struct {
+	include []*rule
+	exclude []*rule
+}
+
+func NewMethodFilter(params Params) (*MethodFilter, error) {
+	include, err := newRuleList("include", params.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := newRuleList("exclude", params.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	includeRegex, err := newRegexRuleList("include-regex", params.IncludeRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeRegex, err := newRegexRuleList("exclude-regex", params.ExcludeRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MethodFilter{
+		include: append(include, includeRegex...),
+		exclude: append(exclude, excludeRegex...),
+	}, nil
+}
+
+// Match reports whether the method of the type is included to the generated code.
+// All rules matching the method are marked as used.
+func (f *MethodFilter) Match(typeName, methodName string, directives astpkg.DirectiveList) bool {
+	_, ignored := directives.GetByName(IgnoreDirective)
+	if f == nil {
+		return !ignored
+	}
+
+	excluded := ignored
+	for _, item := range f.exclude {
+		if item.match(typeName, methodName) {
+			item.matched = true
+			excluded = true
+		}
+	}
+
+	restricted, included := false, false
+	for _, item := range f.include {
+		restricted = restricted || item.restricts(typeName)
+		if item.match(typeName, methodName) {
+			item.matched = true
+			included = true
+		}
+	}
+
+	return !excluded && (!restricted || included)
+}
+
+// Skip marks the rules matching the method which is skipped by the generator before the filter,
+// the rules which have matched only the skipped methods are reported with the reason: outside the method set.
+func (f *MethodFilter) Skip(typeName, methodName, reason string) {
+	if f == nil {
+		return
+	}
+
+	for _, item := range slices.Concat(f.include, f.exclude) {
+		if item.match(typeName, methodName) {
+			item.skipReason = reason
+		}
+	}
+}
+
+// Err returns the error with the rules which have not matched any method.
+func (f *MethodFilter) Err() error {
+	if f == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, item := range slices.Concat(f.include, f.exclude) {
+		switch {
+		case item.matched:
+		case item.skipReason != "":
+			errs = append(errs, fmt.Errorf("%s rule matches only the methods %s: %s", item.kind, item.skipReason, item.value))
+		default:
+			errs = append(errs, fmt.Errorf("%s rule matches nothing: %s", item.kind, item.value))
+		}
+	}
+
+	return errors.Join(errs...)
+}
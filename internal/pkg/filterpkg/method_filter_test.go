@@ -0,0 +1,147 @@
+package filterpkg
+
+import (
+	"testing"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodFilter(t *testing.T) {
+	t.Parallel()
+
+	type method struct {
+		TypeName   string
+		Name       string
+		Directives astpkg.DirectiveList
+	}
+
+	ignored := astpkg.DirectiveList{{Name: IgnoreDirective, Args: map[string]string{}}}
+	methods := []method{
+		{TypeName: "Service", Name: "Get"},
+		{TypeName: "Service", Name: "SetName"},
+		{TypeName: "Service", Name: "Reset", Directives: ignored},
+		{TypeName: "Store", Name: "Get"},
+		{TypeName: "Store", Name: "SetLimit"},
+	}
+
+	testCases := map[string]struct {
+		params   Params
+		expected []string
+		err      string
+	}{
+		"empty": {
+			expected: []string{"Service.Get", "Service.SetName", "Store.Get", "Store.SetLimit"},
+		},
+		"include of all types": {
+			params:   Params{Include: []string{"Get"}},
+			expected: []string{"Service.Get", "Store.Get"},
+		},
+		"include of type": {
+			params:   Params{Include: []string{"Service.Get"}},
+			expected: []string{"Service.Get", "Store.Get", "Store.SetLimit"},
+		},
+		"include of ignored": {
+			params:   Params{Include: []string{"Service.Reset"}},
+			expected: []string{"Store.Get", "Store.SetLimit"},
+		},
+		"exclude": {
+			params:   Params{Exclude: []string{"Store.Get", "SetName"}},
+			expected: []string{"Service.Get", "Store.SetLimit"},
+		},
+		"regex": {
+			params:   Params{IncludeRegex: []string{`^Service\.`}, ExcludeRegex: []string{`\.Set`}},
+			expected: []string{"Service.Get"},
+		},
+		"exclude wins": {
+			params:   Params{Include: []string{"Get"}, Exclude: []string{"Store.Get"}},
+			expected: []string{"Service.Get"},
+		},
+		"matches nothing": {
+			params:   Params{Include: []string{"Service.Find"}, ExcludeRegex: []string{`^Cache\.`}},
+			expected: []string{"Store.Get", "Store.SetLimit"},
+			err: "include rule matches nothing: Service.Find\n" +
+				"exclude-regex rule matches nothing: ^Cache\\.",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := NewMethodFilter(tc.params)
+			require.NoError(t, err)
+
+			matched := make([]string, 0)
+			for _, item := range methods {
+				if filter.Match(item.TypeName, item.Name, item.Directives) {
+					matched = append(matched, item.TypeName+"."+item.Name)
+				}
+			}
+			require.Equal(t, tc.expected, matched)
+
+			if tc.err == "" {
+				require.NoError(t, filter.Err())
+			} else {
+				require.EqualError(t, filter.Err(), tc.err)
+			}
+		})
+	}
+}
+
+func TestMethodFilterSkip(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewMethodFilter(Params{
+		Include: []string{"Service.Get", "Service.SetName"},
+		Exclude: []string{"Service.Find"},
+	})
+	require.NoError(t, err)
+
+	filter.Skip("Service", "SetName", "outside the method set")
+	require.True(t, filter.Match("Service", "Get", nil))
+
+	require.EqualError(t, filter.Err(), "include rule matches only the methods outside the method set: Service.SetName\n"+
+		"exclude rule matches nothing: Service.Find")
+}
+
+func TestNewMethodFilterInvalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		params Params
+		err    string
+	}{
+		"empty method": {
+			params: Params{Include: []string{"Service."}},
+			err:    "invalid include rule: Service.",
+		},
+		"empty type": {
+			params: Params{Exclude: []string{".Get"}},
+			err:    "invalid exclude rule: .Get",
+		},
+		"regex": {
+			params: Params{ExcludeRegex: []string{"("}},
+			err:    "compile exclude-regex rule((): error parsing regexp: missing closing ): `(`",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewMethodFilter(tc.params)
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestNilMethodFilter(t *testing.T) {
+	t.Parallel()
+
+	var filter *MethodFilter
+	filter.Skip("Service", "Get", "outside the method set")
+	require.True(t, filter.Match("Service", "Get", nil))
+	require.False(t, filter.Match("Service", "Get", astpkg.DirectiveList{{Name: IgnoreDirective}}))
+	require.NoError(t, filter.Err())
+}
@@ -0,0 +1,39 @@
+package filterpkg
+
+import "github.com/samber/lo"
+
+// NameFilter selects the names by the rules: <Name> for all scopes or <Scope>.<Name>,
+// the scope is the method of the parameter or the type of the method.
+type NameFilter struct {
+	rules []*rule
+}
+
+func NewNameFilter(kind string, values []string) (*NameFilter, error) {
+	rules, err := newRuleList(kind, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NameFilter{rules: rules}, nil
+}
+
+// Match reports whether the name of the scope matches at least one rule.
+// All rules matching the name are marked as used.
+func (f *NameFilter) Match(scope, name string) bool {
+	matched := false
+	for _, item := range f.rules {
+		if item.match(scope, name) {
+			item.matched = true
+			matched = true
+		}
+	}
+
+	return matched
+}
+
+// Unmatched returns the rules which have not matched any name.
+func (f *NameFilter) Unmatched() []string {
+	return lo.FilterMap(f.rules, func(item *rule, _ int) (string, bool) {
+		return item.value, !item.matched
+	})
+}
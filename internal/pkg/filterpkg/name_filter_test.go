@@ -0,0 +1,69 @@
+package filterpkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameFilter(t *testing.T) {
+	t.Parallel()
+
+	type name struct {
+		Scope string
+		Name  string
+	}
+
+	names := []name{
+		{Scope: "Login", Name: "password"},
+		{Scope: "Login", Name: "user"},
+		{Scope: "Find", Name: "ids"},
+	}
+
+	testCases := map[string]struct {
+		values    []string
+		expected  []string
+		unmatched []string
+	}{
+		"empty": {
+			expected:  []string{},
+			unmatched: []string{},
+		},
+		"all scopes and scope": {
+			// both rules match the same name
+			values:    []string{"password", "Login.password"},
+			expected:  []string{"Login.password"},
+			unmatched: []string{},
+		},
+		"other scope": {
+			values:    []string{"Find.password", "ids"},
+			expected:  []string{"Find.ids"},
+			unmatched: []string{"Find.password"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := NewNameFilter("redact", tc.values)
+			require.NoError(t, err)
+
+			matched := make([]string, 0)
+			for _, item := range names {
+				if filter.Match(item.Scope, item.Name) {
+					matched = append(matched, item.Scope+"."+item.Name)
+				}
+			}
+			require.Equal(t, tc.expected, matched)
+			require.Equal(t, tc.unmatched, filter.Unmatched())
+		})
+	}
+}
+
+func TestNewNameFilterInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewNameFilter("redact", []string{"Login."})
+	require.EqualError(t, err, "invalid redact rule: Login.")
+}
@@ -0,0 +1,138 @@
+package interfacepkg
+
+import (
+	"fmt"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/samber/lo"
+)
+
+// File is the generated file of the interface.
+type File struct {
+	Imports astpkg.ImportList
+	Spec    Spec
+}
+
+type PrepareFileListParams struct {
+	InterfaceTypes []InterfaceType
+	TargetDir      string
+	// Imports are the packages used by the generated code, they are imported with the names
+	// of the packages if the names are not used by the packages of the interfaces.
+	Imports []string
+	// UsedImports returns the packages of Imports used by the generated code of the interface, optional:
+	// all Imports are used by default.
+	UsedImports func(spec *Spec) []string
+	// Reserved are the names used by the generated code, the arguments of the methods are renamed.
+	Reserved []string
+	// NameKey returns the key of the argument name which is unique for the method, optional: the name by default.
+	// The generated code deriving the identifiers from the names sets it: Name and name are the same field Name.
+	NameKey func(name string) string
+	Cache   *astpkg.PackageCache
+}
+
+// PrepareFileList parses the packages of the interfaces and returns the specifications of the generated files.
+func PrepareFileList(params PrepareFileListParams) ([]File, error) {
+	targetPackage, err := astpkg.GetPackagePath(params.TargetDir)
+	if err != nil {
+		return nil, fmt.Errorf("get target package: %w", err)
+	}
+
+	packagePathList := lo.Uniq(lo.Map(params.InterfaceTypes, func(item InterfaceType, _ int) string {
+		return item.Package
+	}))
+
+	res := make([]File, 0, len(params.InterfaceTypes))
+	for _, pkgPath := range packagePathList {
+		pkg, err := params.Cache.ParsePackage(pkgPath)
+		if err != nil {
+			return nil, fmt.Errorf("parse package(%s): %w", pkgPath, err)
+		}
+
+		if err := astpkg.InitSelfPackageImports(targetPackage, pkg); err != nil {
+			return nil, fmt.Errorf("init self package imports: %w", err)
+		}
+
+		interfacePackage := lo.Ternary(pkgPath == targetPackage, "", pkgPath)
+
+		baseImports := append([]string{""}, params.Imports...)
+		imports, err := astpkg.GetAllPackagesImports(append(baseImports, interfacePackage), pkg)
+		if err != nil {
+			return nil, fmt.Errorf("get all imports: %w", err)
+		}
+
+		for _, item := range params.InterfaceTypes {
+			if item.Package != pkgPath {
+				continue
+			}
+
+			typeDecl, ok := pkg.TypeDeclList.GetByName(item.TypeName)
+			if !ok {
+				return nil, fmt.Errorf("not found type: %s", item.TypeName)
+			}
+
+			item.Package = interfacePackage
+
+			spec, err := newSpec(newSpecParams{
+				interfaceType: item,
+				typeDecl:      typeDecl,
+				imports:       imports,
+				samePackage:   interfacePackage == "",
+				reserved:      params.Reserved,
+				nameKey:       params.NameKey,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("new specification(%s): %w", item.Name, err)
+			}
+
+			usedImports := params.Imports
+			if params.UsedImports != nil {
+				usedImports = params.UsedImports(spec)
+			}
+
+			res = append(res, File{
+				Imports: clearImports(imports, targetPackage, interfacePackage, usedImports, typeDecl, spec),
+				Spec:    *spec,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+func clearImports(
+	imports astpkg.ImportList,
+	targetPackage string,
+	interfacePackage string,
+	requiredImports []string,
+	typeDecl *astpkg.TypeDecl,
+	spec *Spec,
+) astpkg.ImportList {
+	usedImports := make(map[string]struct{})
+	addUsedImport := func(t astpkg.Type) {
+		for _, item := range t.Imports() {
+			usedImports[item.Alias] = struct{}{}
+		}
+	}
+
+	for _, path := range append([]string{interfacePackage}, requiredImports...) {
+		if imp, ok := imports.GetByPath(path); ok {
+			usedImports[imp.Alias] = struct{}{}
+		}
+	}
+	for _, item := range typeDecl.TypeParams {
+		addUsedImport(item.Type)
+	}
+	for _, method := range spec.Methods {
+		for _, item := range method.Params {
+			addUsedImport(item.Type)
+		}
+		for _, item := range method.Results {
+			addUsedImport(item.Type)
+		}
+	}
+
+	return lo.Filter(imports, func(item astpkg.Import, _ int) bool {
+		_, used := usedImports[item.Alias]
+		return item.Path != "" && item.Path != targetPackage && used
+	})
+}
@@ -0,0 +1,116 @@
+package interfacepkg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareFileList(t *testing.T) {
+	t.Parallel()
+
+	list, err := PrepareFileList(PrepareFileListParams{
+		InterfaceTypes: []InterfaceType{{
+			Package:  "github.com/khevse/codegen/tests/decoratorpkg",
+			TypeName: "IService",
+			Name:     "Service",
+		}},
+		TargetDir: "./",
+		Imports:   []string{"time", "log/slog"},
+		UsedImports: func(*Spec) []string {
+			return []string{"time"}
+		},
+		Reserved: []string{"user"},
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	file := list[0]
+	require.Equal(t,
+		astpkg.ImportList{
+			{Alias: "time", Path: "time"},
+			{Alias: "decoratorpkg", Path: "github.com/khevse/codegen/tests/decoratorpkg"},
+			{Alias: "context", Path: "context"},
+		},
+		file.Imports,
+	)
+
+	spec := file.Spec
+	require.Equal(t, "Service", spec.Name)
+	require.Equal(t, "decoratorpkg.IService", spec.InterfaceName)
+	require.Equal(t, "IService", spec.TypeName)
+	require.Equal(t, "IService service of the users", spec.Comment)
+
+	type methodView struct {
+		ParamsDecl   string
+		ResultsDecl  string
+		CallArgs     string
+		ResultNames  string
+		ContextParam string
+		ErrorResult  string
+	}
+	got := make(map[string]methodView)
+	for _, method := range spec.Methods {
+		got[method.Name] = methodView{
+			ParamsDecl:   method.ParamsDecl(),
+			ResultsDecl:  method.ResultsDecl(),
+			CallArgs:     method.CallArgs(),
+			ResultNames:  method.ResultNames(),
+			ContextParam: method.ContextParam(),
+			ErrorResult:  method.ErrorResult(),
+		}
+	}
+
+	want := map[string]methodView{
+		"Login": {
+			// the reserved name is replaced
+			ParamsDecl:   "ctx context.Context, p1 string, password string",
+			ResultsDecl:  "(token string, err error)",
+			CallArgs:     "ctx, p1, password",
+			ResultNames:  "token, err",
+			ContextParam: "ctx",
+			ErrorResult:  "err",
+		},
+		"Find": {
+			ParamsDecl:   "ctx context.Context, ids ...int",
+			ResultsDecl:  "(r0 []decoratorpkg.User, r1 error)",
+			CallArgs:     "ctx, ids...",
+			ResultNames:  "r0, r1",
+			ContextParam: "ctx",
+			ErrorResult:  "r1",
+		},
+		"Ping": {
+			ResultsDecl: "(r0 error)",
+			ResultNames: "r0",
+			ErrorResult: "r0",
+		},
+		"Name": {
+			ResultsDecl: "(r0 string)",
+			ResultNames: "r0",
+		},
+		"Close": {},
+	}
+	require.Empty(t, cmp.Diff(want, got))
+}
+
+func TestPrepareFileListErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not found type", func(t *testing.T) {
+		_, err := PrepareFileList(PrepareFileListParams{
+			InterfaceTypes: []InterfaceType{{Package: "github.com/khevse/codegen/tests/decoratorpkg", TypeName: "Unknown"}},
+			TargetDir:      "./",
+		})
+		require.EqualError(t, err, "not found type: Unknown")
+	})
+
+	t.Run("not interface", func(t *testing.T) {
+		_, err := PrepareFileList(PrepareFileListParams{
+			InterfaceTypes: []InterfaceType{{Package: "github.com/khevse/codegen/tests/decoratorpkg", TypeName: "User", Name: "UserLogger"}},
+			TargetDir:      "./",
+		})
+		require.EqualError(t, err, "new specification(UserLogger): type is not interface")
+	})
+}
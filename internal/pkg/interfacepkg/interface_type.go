@@ -0,0 +1,49 @@
+package interfacepkg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// InterfaceType is the interface of the command flag: <package>.<InterfaceName>=<Name>,
+// where Name is the name of the generated type.
+type InterfaceType struct {
+	Package  string
+	TypeName string
+	Name     string
+}
+
+// ParseInterfaceTypeList parses the list of the interfaces: <package>.<InterfaceName1>,<package>.<InterfaceName2>=<Name2>.
+// The name of the generated type is <InterfaceName><defaultSuffix> if it is not set.
+func ParseInterfaceTypeList(val, defaultSuffix string) ([]InterfaceType, error) {
+	list := make([]InterfaceType, 0)
+
+	for _, part := range strings.Split(val, ",") {
+		part = strings.TrimSpace(part)
+
+		fromTypeDelimiterIdx := strings.LastIndex(part, ".")
+		if fromTypeDelimiterIdx == -1 {
+			return nil, fmt.Errorf("invalid type: %s", part)
+		}
+
+		packageName := part[:fromTypeDelimiterIdx]
+		types := part[fromTypeDelimiterIdx+1:]
+
+		typesParts := strings.Split(types, "=")
+		typeName := typesParts[0]
+		name := typesParts[0] + defaultSuffix
+		if len(typesParts) > 1 {
+			name = typesParts[1]
+		}
+
+		list = append(list, InterfaceType{
+			Package:  packageName,
+			TypeName: typeName,
+			Name:     name,
+		})
+	}
+
+	return lo.Uniq(list), nil
+}
@@ -0,0 +1,36 @@
+package interfacepkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterfaceTypeList(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success list with name", func(t *testing.T) {
+		res, err := ParseInterfaceTypeList("github.com/package.IType1, github.com/package.IType2=Type2Logger", "Logger")
+		require.NoError(t, err)
+		require.Equal(t,
+			[]InterfaceType{
+				{
+					Package:  "github.com/package",
+					TypeName: "IType1",
+					Name:     "IType1Logger",
+				},
+				{
+					Package:  "github.com/package",
+					TypeName: "IType2",
+					Name:     "Type2Logger",
+				},
+			},
+			res,
+		)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := ParseInterfaceTypeList("IType", "Logger")
+		require.EqualError(t, err, "invalid type: IType")
+	})
+}
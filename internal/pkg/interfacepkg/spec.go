@@ -0,0 +1,262 @@
+package interfacepkg
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/samber/lo"
+)
+
+// Field is the argument of the method.
+type Field struct {
+	// Name is the name of the argument, it is unique for the method.
+	Name string
+	// SourceName is the name of the argument in the interface, it is empty for the unnamed arguments.
+	SourceName string
+	// TypeName is the type in the method signature.
+	TypeName string
+	Type     astpkg.Type
+}
+
+// IsVariadic returns true for the variadic argument: ...T.
+func (f Field) IsVariadic() bool {
+	_, ok := f.Type.(*astpkg.EllipsisType)
+	return ok
+}
+
+// IsContext returns true if the type is context.Context.
+func (f Field) IsContext() bool {
+	switch casted := f.Type.(type) {
+	case *astpkg.SelectorExpr:
+		return casted.PackagePath == "context" && casted.Name == "Context"
+	case *astpkg.Ident:
+		return casted.PackagePath == "context" && casted.Name == "Context"
+	default:
+		return false
+	}
+}
+
+// IsError returns true if the type is the predeclared error.
+func (f Field) IsError() bool {
+	casted, ok := f.Type.(*astpkg.Ident)
+	return ok && casted.Name == "error" && casted.Package == "" && casted.Type == nil
+}
+
+// Method is the method of the interface.
+type Method struct {
+	Name    string
+	Params  []Field
+	Results []Field
+}
+
+// ParamsDecl returns the parameters of the method signature: ctx context.Context, ids ...int.
+func (m Method) ParamsDecl() string {
+	return joinFields(m.Params, func(item Field) string {
+		return item.Name + " " + item.TypeName
+	})
+}
+
+// ResultsDecl returns the named results of the method signature: (r0 T, err error).
+func (m Method) ResultsDecl() string {
+	if len(m.Results) == 0 {
+		return ""
+	}
+
+	return "(" + joinFields(m.Results, func(item Field) string {
+		return item.Name + " " + item.TypeName
+	}) + ")"
+}
+
+// CallArgs returns the arguments of the method call: ctx, ids...
+func (m Method) CallArgs() string {
+	return joinFields(m.Params, func(item Field) string {
+		if item.IsVariadic() {
+			return item.Name + "..."
+		}
+		return item.Name
+	})
+}
+
+// ResultNames returns the names of the results: r0, err.
+func (m Method) ResultNames() string {
+	return joinFields(m.Results, func(item Field) string {
+		return item.Name
+	})
+}
+
+// ContextParam returns the name of the first context.Context parameter, it is empty if the method has no context.
+func (m Method) ContextParam() string {
+	item, _ := lo.Find(m.Params, func(item Field) bool {
+		return item.IsContext()
+	})
+
+	return item.Name
+}
+
+// ErrorResult returns the name of the last result if it is error, it is empty otherwise.
+func (m Method) ErrorResult() string {
+	if len(m.Results) == 0 || !m.Results[len(m.Results)-1].IsError() {
+		return ""
+	}
+
+	return m.Results[len(m.Results)-1].Name
+}
+
+// Spec is the specification of the type generated for the interface.
+type Spec struct {
+	// Name is the name of the generated type.
+	Name string
+	// InterfaceName is the interface in the generated code: pkg.IService[T].
+	InterfaceName string
+	// TypeName is the name of the interface in the source package: IService.
+	TypeName   string
+	TypeParams string
+	TypeArgs   string
+	Comment    string
+	Methods    []Method
+}
+
+type newSpecParams struct {
+	interfaceType InterfaceType
+	typeDecl      *astpkg.TypeDecl
+	imports       astpkg.ImportList
+	samePackage   bool
+	reserved      []string
+	nameKey       func(name string) string
+}
+
+func newSpec(params newSpecParams) (*Spec, error) {
+	castedType, ok := astpkg.CastToType[astpkg.InterfaceType](params.typeDecl.Type)
+	if !ok {
+		return nil, errors.New("type is not interface")
+	}
+
+	methods, err := astpkg.GetInterfaceMethods(castedType)
+	if err != nil {
+		return nil, fmt.Errorf("get interface methods: %w", err)
+	}
+
+	for _, item := range methods {
+		if err := astpkg.ReplaceImportAliasByImportPath(item.Type, params.imports); err != nil {
+			return nil, fmt.Errorf("replace method imports(%s): %w", item.Name, err)
+		}
+	}
+
+	for _, item := range params.typeDecl.TypeParams {
+		if err := astpkg.ReplaceImportAliasByImportPath(item.Type, params.imports); err != nil {
+			return nil, fmt.Errorf("replace type param imports(%s): %w", item.Name, err)
+		}
+	}
+
+	interfacePackage, ok := params.imports.GetByPath(params.interfaceType.Package)
+	if !ok {
+		return nil, fmt.Errorf("get interface type package: %s", params.interfaceType.Package)
+	}
+
+	interfaceName := params.interfaceType.TypeName
+	if interfacePackage.Alias != "" {
+		interfaceName = fmt.Sprintf("%s.%s", interfacePackage.Alias, interfaceName)
+	}
+	typeArgs := astpkg.TypeParamsArgs(params.typeDecl.TypeParams)
+
+	methodList := make([]Method, 0, len(methods))
+	for _, item := range methods {
+		if !astpkg.IsExported(item.Name) && !params.samePackage {
+			return nil, fmt.Errorf("unexported method %s can not be implemented in other package", item.Name)
+		}
+
+		casedMethod, ok := item.Type.(*astpkg.FuncType)
+		if !ok {
+			return nil, fmt.Errorf("cast method type(%s): %T", item.Name, item.Type)
+		}
+
+		// the arguments are in the scope of the type parameters of the generated type
+		reserved := slices.Concat(params.reserved, lo.Map(params.typeDecl.TypeParams, func(p *astpkg.Field, _ int) string {
+			return p.Name
+		}))
+		names := newNameSet(params.nameKey, reserved...)
+
+		methodList = append(methodList, Method{
+			Name:    item.Name,
+			Params:  newFieldsList(casedMethod.Params, "p", names),
+			Results: newFieldsList(casedMethod.Results, "r", names),
+		})
+	}
+
+	return &Spec{
+		Name:          params.interfaceType.Name,
+		InterfaceName: interfaceName + typeArgs,
+		TypeName:      params.interfaceType.TypeName,
+		TypeParams:    astpkg.TypeParamsDecl(params.typeDecl.TypeParams),
+		TypeArgs:      typeArgs,
+		Comment:       params.typeDecl.Comment,
+		Methods:       methodList,
+	}, nil
+}
+
+// nameSet is the set of the names used in the method: the reserved names and the names of the arguments,
+// the arguments are compared by the keys too.
+type nameSet struct {
+	key   func(name string) string
+	names map[string]struct{}
+	keys  map[string]struct{}
+}
+
+func newNameSet(key func(name string) string, reserved ...string) *nameSet {
+	set := &nameSet{
+		key:   lo.Ternary(key != nil, key, func(name string) string { return name }),
+		names: make(map[string]struct{}, len(reserved)),
+		keys:  make(map[string]struct{}),
+	}
+	for _, name := range reserved {
+		set.names[name] = struct{}{}
+	}
+
+	return set
+}
+
+func (s *nameSet) contains(name string) bool {
+	_, usedName := s.names[name]
+	_, usedKey := s.keys[s.key(name)]
+	return usedName || usedKey
+}
+
+// uniq returns the name if it is not used, the name is replaced by the prefix with the index otherwise:
+// p1, or p1_1 if p1 is used too. The returned name is added to the set.
+func (s *nameSet) uniq(name, prefix string, idx int) string {
+	if name == "" || name == "_" || s.contains(name) {
+		name = fmt.Sprintf("%s%d", prefix, idx)
+		for i := 1; s.contains(name); i++ {
+			name = fmt.Sprintf("%s%d_%d", prefix, idx, i)
+		}
+	}
+	s.names[name] = struct{}{}
+	s.keys[s.key(name)] = struct{}{}
+
+	return name
+}
+
+// newFieldsList returns the arguments with the names which are unique for the method: the empty
+// names and the names which are already used are replaced by the prefix with the argument index.
+func newFieldsList(src []*astpkg.Field, prefix string, names *nameSet) []Field {
+	fieldList := make([]Field, 0, len(src))
+	for i, item := range src {
+		fieldList = append(fieldList, Field{
+			Name:       names.uniq(item.Name, prefix, i),
+			SourceName: lo.Ternary(item.Name == "_", "", item.Name),
+			TypeName:   item.Type.ExprString(),
+			Type:       item.Type,
+		})
+	}
+
+	return fieldList
+}
+
+func joinFields(fieldList []Field, fn func(item Field) string) string {
+	return strings.Join(lo.Map(fieldList, func(item Field, _ int) string {
+		return fn(item)
+	}), ", ")
+}
@@ -0,0 +1,155 @@
+package pluginpkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// ExecutablePrefix is the prefix of the names of the plugin executables: codegen-<name>.
+const ExecutablePrefix = "codegen-"
+
+// defaultWindowsExts is the list of the executable extensions used on Windows if PATHEXT is empty.
+const defaultWindowsExts = ".com;.exe;.bat;.cmd"
+
+// Plugin is the executable generating the files.
+type Plugin struct {
+	Name string
+	Path string
+}
+
+// Find returns the plugins from the dirs of the PATH list sorted by the name. The plugin
+// of the first dir is used if the dirs contain the plugins with the same name, as the shell does.
+// The unreadable dirs are skipped. On Windows only the files with the extensions of PATHEXT
+// are the plugins, the extension is not a part of the plugin name.
+func Find(pathList string) []Plugin {
+	res := make([]Plugin, 0)
+	found := make(map[string]struct{})
+
+	var exts []string
+	if runtime.GOOS == "windows" {
+		exts = getWindowsExts(os.Getenv("PATHEXT"))
+	}
+
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name, ok := getPluginName(entry.Name(), exts)
+			if !ok {
+				continue
+			}
+			if _, ok := found[name]; ok {
+				continue
+			}
+
+			filePath := filepath.Join(dir, entry.Name())
+			if !isExecutable(filePath) {
+				continue
+			}
+
+			found[name] = struct{}{}
+			res = append(res, Plugin{Name: name, Path: filePath})
+		}
+	}
+
+	slices.SortFunc(res, func(i, j Plugin) int {
+		return strings.Compare(i.Name, j.Name)
+	})
+
+	return res
+}
+
+// Run executes the plugin: the request is written to stdin, the response is read from stdout,
+// stderr of the plugin is written to stderr.
+func (p Plugin) Run(req Request) (*Response, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+
+	output := bytes.NewBuffer(nil)
+
+	cmd := exec.Command(p.Path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = output
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run plugin(%s): %w", p.Path, err)
+	}
+
+	res := new(Response)
+	if err := json.Unmarshal(output.Bytes(), res); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return res, nil
+}
+
+// getPluginName returns the plugin name of the file. If exts is not empty the file must have
+// one of the extensions, the comparison is case-insensitive as on Windows.
+func getPluginName(fileName string, exts []string) (string, bool) {
+	if len(exts) != 0 {
+		ext := filepath.Ext(fileName)
+		if !slices.Contains(exts, strings.ToLower(ext)) {
+			return "", false
+		}
+		fileName = strings.TrimSuffix(fileName, ext)
+	}
+
+	name, ok := strings.CutPrefix(fileName, ExecutablePrefix)
+	if !ok || name == "" || strings.HasPrefix(name, ".") {
+		return "", false
+	}
+
+	return name, true
+}
+
+func isExecutable(filePath string) bool {
+	info, err := os.Stat(filePath)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+
+	// on Windows the extension of the file is checked by getPluginName
+	if runtime.GOOS == "windows" {
+		return true
+	}
+
+	return info.Mode().Perm()&0o111 != 0
+}
+
+// getWindowsExts returns the lower-cased extensions of the PATHEXT value.
+func getWindowsExts(pathExt string) []string {
+	if pathExt == "" {
+		pathExt = defaultWindowsExts
+	}
+
+	res := make([]string, 0)
+	for _, ext := range strings.Split(pathExt, ";") {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		res = append(res, ext)
+	}
+
+	return res
+}
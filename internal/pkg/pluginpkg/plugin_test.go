@@ -0,0 +1,139 @@
+package pluginpkg
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+func writeScript(t *testing.T, filePath, script string, mode os.FileMode) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filePath, []byte("#!/bin/sh\n"+script), mode))
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugins are shell scripts")
+	}
+
+	firstDir := t.TempDir()
+	secondDir := t.TempDir()
+
+	writeScript(t, filepath.Join(firstDir, "codegen-logger"), "", 0o755)
+	writeScript(t, filepath.Join(firstDir, "codegen-"), "", 0o755)
+	writeScript(t, filepath.Join(firstDir, "codegen-not-executable"), "", 0o644)
+	writeScript(t, filepath.Join(firstDir, "other"), "", 0o755)
+	require.NoError(t, os.Mkdir(filepath.Join(firstDir, "codegen-dir"), 0o755))
+	writeScript(t, filepath.Join(secondDir, "codegen-logger"), "", 0o755)
+	writeScript(t, filepath.Join(secondDir, "codegen-adapter"), "", 0o755)
+
+	pathList := filepath.Join(firstDir, "unknown") + string(filepath.ListSeparator) +
+		firstDir + string(filepath.ListSeparator) +
+		secondDir
+
+	want := []Plugin{
+		{Name: "adapter", Path: filepath.Join(secondDir, "codegen-adapter")},
+		{Name: "logger", Path: filepath.Join(firstDir, "codegen-logger")},
+	}
+	require.Empty(t, cmp.Diff(want, Find(pathList)))
+}
+
+func TestGetPluginName(t *testing.T) {
+	t.Parallel()
+
+	windowsExts := getWindowsExts(".COM;.EXE; .Bat;;cmd")
+	require.Equal(t, []string{".com", ".exe", ".bat", ".cmd"}, windowsExts)
+	require.Equal(t, []string{".com", ".exe", ".bat", ".cmd"}, getWindowsExts(""))
+
+	for name, tc := range map[string]struct {
+		FileName string
+		Exts     []string
+		Want     string
+		WantOK   bool
+	}{
+		"unix":                       {FileName: "codegen-logger", Want: "logger", WantOK: true},
+		"unix with extension":        {FileName: "codegen-notes.txt", Want: "notes.txt", WantOK: true},
+		"unix without prefix":        {FileName: "logger"},
+		"unix without name":          {FileName: "codegen-"},
+		"unix hidden":                {FileName: "codegen-.logger"},
+		"windows exe":                {FileName: "codegen-logger.exe", Exts: windowsExts, Want: "logger", WantOK: true},
+		"windows upper-cased ext":    {FileName: "codegen-logger.CMD", Exts: windowsExts, Want: "logger", WantOK: true},
+		"windows not executable ext": {FileName: "codegen-notes.txt", Exts: windowsExts},
+		"windows without ext":        {FileName: "codegen-logger", Exts: windowsExts},
+		"windows without name":       {FileName: "codegen-.exe", Exts: windowsExts},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := getPluginName(tc.FileName, tc.Exts)
+			require.Equal(t, tc.WantOK, ok)
+			require.Equal(t, tc.Want, got)
+		})
+	}
+}
+
+func TestPluginRun(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugins are shell scripts")
+	}
+
+	dir := t.TempDir()
+	requestFile := filepath.Join(dir, "request.json")
+
+	// the scripts are written before the execution: a file opened for writing
+	// by a parallel test can not be executed
+	successFile := filepath.Join(dir, "codegen-success")
+	writeScript(t, successFile, `cat > `+requestFile+`
+printf '%s' '{"files":[{"path":"file.go","content":"package pkg\n"}]}'
+`, 0o755)
+	failureFile := filepath.Join(dir, "codegen-failure")
+	writeScript(t, failureFile, "exit 3\n", 0o755)
+	invalidFile := filepath.Join(dir, "codegen-invalid")
+	writeScript(t, invalidFile, "echo files\n", 0o755)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		req := Request{
+			Version:   Version,
+			Command:   "success",
+			TargetDir: dir,
+			Args:      map[string]string{"name": "value"},
+		}
+
+		res, err := Plugin{Name: "success", Path: successFile}.Run(req)
+		require.NoError(t, err)
+		require.Empty(t, cmp.Diff(&Response{Files: []File{{Path: "file.go", Content: "package pkg\n"}}}, res))
+
+		data, err := os.ReadFile(requestFile)
+		require.NoError(t, err)
+
+		var gotReq Request
+		require.NoError(t, json.Unmarshal(data, &gotReq))
+		require.Empty(t, cmp.Diff(req, gotReq))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Plugin{Name: "failure", Path: failureFile}.Run(Request{})
+		require.EqualError(t, err, "run plugin("+failureFile+"): exit status 3")
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Plugin{Name: "invalid", Path: invalidFile}.Run(Request{})
+		require.ErrorContains(t, err, "decode response:")
+	})
+}
@@ -0,0 +1,48 @@
+package pluginpkg
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+)
+
+// Version is the version of the plugin protocol, it is changed on the incompatible changes.
+const Version = 1
+
+// Request is written to stdin of the plugin as JSON.
+type Request struct {
+	Version int    `json:"version"`
+	Command string `json:"command"`
+	// TargetDir is the absolute path of the dir of the generated files
+	TargetDir string `json:"target_dir"`
+	// Args are the plugin arguments: --arg=<name>=<value>
+	Args     map[string]string      `json:"args"`
+	Packages []astpkg.PackageSchema `json:"packages"`
+}
+
+// Response is read from stdout of the plugin as JSON.
+type Response struct {
+	Files []File `json:"files"`
+}
+
+// File is the generated file, the relative path is resolved from the target dir.
+type File struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// ResolvePath returns the path of the file in the target dir,
+// the absolute paths and the paths outside the target dir are rejected.
+func (f File) ResolvePath(targetDir string) (string, error) {
+	if f.Path == "" {
+		return "", errors.New("plugin returned the file without path")
+	}
+
+	if !filepath.IsLocal(f.Path) {
+		return "", fmt.Errorf("plugin returned the file outside the target dir: %s", f.Path)
+	}
+
+	return filepath.Join(targetDir, filepath.Clean(f.Path)), nil
+}
@@ -0,0 +1,53 @@
+package pluginpkg
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileResolvePath(t *testing.T) {
+	t.Parallel()
+
+	targetDir := filepath.Join(t.TempDir(), "target")
+
+	for name, tc := range map[string]struct {
+		Path     string
+		WantPath string
+		WantErr  string
+	}{
+		"relative": {
+			Path:     "logger.go",
+			WantPath: filepath.Join(targetDir, "logger.go"),
+		},
+		"nested": {
+			Path:     "logger/../gen/./logger.go",
+			WantPath: filepath.Join(targetDir, "gen", "logger.go"),
+		},
+		"empty": {
+			WantErr: "plugin returned the file without path",
+		},
+		"absolute": {
+			Path:    filepath.Join(targetDir, "logger.go"),
+			WantErr: "plugin returned the file outside the target dir: " + filepath.Join(targetDir, "logger.go"),
+		},
+		"parent": {
+			Path:    "gen/../../logger.go",
+			WantErr: "plugin returned the file outside the target dir: gen/../../logger.go",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := File{Path: tc.Path}.ResolvePath(targetDir)
+			if tc.WantErr != "" {
+				require.EqualError(t, err, tc.WantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.WantPath, got)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package stringspkg
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ToCamelCase converts the value to lower camel case: UserID -> userID, user_name -> userName.
+func ToCamelCase(val string) string {
+	words := strings.FieldsFunc(val, func(r rune) bool {
+		return r == '_' || r == '-' || unicode.IsSpace(r)
+	})
+
+	buf := strings.Builder{}
+	for i, word := range words {
+		if i == 0 {
+			buf.WriteString(LowerFirst(word))
+		} else {
+			buf.WriteString(UpperFirst(word))
+		}
+	}
+
+	return buf.String()
+}
+
+// ToKebabCase converts the value to kebab case: UserName -> user-name.
+func ToKebabCase(val string) string {
+	return strings.ReplaceAll(ToSnakeCase(val), "_", "-")
+}
@@ -0,0 +1,23 @@
+package stringspkg
+
+import "unicode"
+
+// UpperFirst converts the first letter to upper case.
+func UpperFirst(val string) string {
+	return mapFirst(val, unicode.ToUpper)
+}
+
+// LowerFirst converts the first letter to lower case.
+func LowerFirst(val string) string {
+	return mapFirst(val, unicode.ToLower)
+}
+
+func mapFirst(val string, fn func(rune) rune) string {
+	runes := []rune(val)
+	if len(runes) == 0 {
+		return val
+	}
+
+	runes[0] = fn(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,21 @@
+package stringspkg
+
+import "strings"
+
+// Pluralize returns the plural form of the english noun by the regular rules: Item -> Items,
+// Box -> Boxes, Entry -> Entries.
+func Pluralize(val string) string {
+	lower := strings.ToLower(val)
+
+	switch {
+	case lower == "":
+		return val
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return val + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return val[:len(val)-1] + "ies"
+	default:
+		return val + "s"
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"go/format"
 	"io"
 	"io/fs"
+	"path/filepath"
 	"text/template"
 )
 
@@ -13,18 +14,20 @@ type ExecuteTemplateParams struct {
 	Writer       io.Writer
 	FS           fs.FS
 	TemplateFile string
-	Data         any
-	Format       bool
+	// CustomTemplateFile is the path of the template file used instead of the TemplateFile, optional
+	CustomTemplateFile string
+	Data               any
+	Format             bool
 }
 
 func ExecuteTemplate(params ExecuteTemplateParams) error {
-	tmpl, err := template.New("").ParseFS(params.FS, params.TemplateFile)
+	tmpl, name, err := parseTemplate(params)
 	if err != nil {
 		return fmt.Errorf("parse template: %w", err)
 	}
 
 	buf := bytes.NewBuffer(nil)
-	if err := tmpl.ExecuteTemplate(buf, params.TemplateFile, params.Data); err != nil {
+	if err := tmpl.ExecuteTemplate(buf, name, params.Data); err != nil {
 		return fmt.Errorf("execute template: %w", err)
 	}
 
@@ -47,3 +50,14 @@ func ExecuteTemplate(params ExecuteTemplateParams) error {
 
 	return nil
 }
+
+// parseTemplate returns the template and the name of the template to execute.
+func parseTemplate(params ExecuteTemplateParams) (*template.Template, string, error) {
+	if params.CustomTemplateFile == "" {
+		tmpl, err := template.New("").Funcs(FuncMap()).ParseFS(params.FS, params.TemplateFile)
+		return tmpl, params.TemplateFile, err
+	}
+
+	tmpl, err := template.New("").Funcs(FuncMap()).ParseFiles(params.CustomTemplateFile)
+	return tmpl, filepath.Base(params.CustomTemplateFile), err
+}
@@ -0,0 +1,211 @@
+package templatepkg
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/stringspkg"
+)
+
+// FuncMap returns the functions available in the templates.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"join":        join,
+		"pluck":       pluck,
+		"joinParams":  joinParams,
+		"joinResults": joinResults,
+		"snakeCase":   stringspkg.ToSnakeCase,
+		"camelCase":   stringspkg.ToCamelCase,
+		"kebabCase":   stringspkg.ToKebabCase,
+		"plural":      stringspkg.Pluralize,
+		"lowerFirst":  stringspkg.LowerFirst,
+		"upperFirst":  stringspkg.UpperFirst,
+		"zeroValue":   astpkg.ZeroValue,
+		"typeKind": func(t astpkg.Type) string {
+			return string(astpkg.GetTypeKind(t))
+		},
+		"isBasic":     isTypeKind(astpkg.TypeKindBasic),
+		"isTypeParam": isTypeKind(astpkg.TypeKindTypeParam),
+		"isStruct":    isTypeKind(astpkg.TypeKindStruct),
+		"isInterface": isTypeKind(astpkg.TypeKindInterface),
+		"isFunc":      isTypeKind(astpkg.TypeKindFunc),
+		"isMap":       isTypeKind(astpkg.TypeKindMap),
+		"isSlice":     isTypeKind(astpkg.TypeKindSlice),
+		"isArray":     isTypeKind(astpkg.TypeKindArray),
+		"isChan":      isTypeKind(astpkg.TypeKindChan),
+		"isPointer":   isTypeKind(astpkg.TypeKindPointer),
+		"isError": func(t astpkg.Type) bool {
+			casted, ok := t.(*astpkg.Ident)
+			return ok && casted.Name == "error" && casted.Package == "" && casted.Type == nil
+		},
+		"isNillable": func(t astpkg.Type) bool {
+			return astpkg.ZeroValue(t) == "nil"
+		},
+		"wrapComment": wrapComment,
+	}
+}
+
+func isTypeKind(kind astpkg.TypeKind) func(t astpkg.Type) bool {
+	return func(t astpkg.Type) bool {
+		return astpkg.GetTypeKind(t) == kind
+	}
+}
+
+// join joins the items of the list by the separator: {{ pluck "Name" .Params | join ", " }}.
+func join(sep string, list any) (string, error) {
+	items, err := toSlice(list)
+	if err != nil {
+		return "", err
+	}
+
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		res = append(res, fmt.Sprint(item.Interface()))
+	}
+
+	return strings.Join(res, sep), nil
+}
+
+// pluck returns the values of the field or method of the list items: {{ pluck "Name" .Params }}.
+func pluck(name string, list any) ([]string, error) {
+	items, err := toSlice(list)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		val, err := getFieldValue(item, name)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, val)
+	}
+
+	return res, nil
+}
+
+// joinParams returns the parameters declaration "id ID, args ...string" from the items with
+// the fields Name and TypeName, the optional argument replaces the field of the name.
+func joinParams(list any, nameField ...string) (string, error) {
+	decl, _, err := joinFields(list, nameField)
+	return decl, err
+}
+
+// joinResults returns the results declaration "(id ID, err error)", "(ID, error)" or "error".
+func joinResults(list any, nameField ...string) (string, error) {
+	decl, named, err := joinFields(list, nameField)
+	if err != nil || decl == "" {
+		return decl, err
+	}
+
+	if !named && !strings.Contains(decl, ",") {
+		return decl, nil
+	}
+
+	return "(" + decl + ")", nil
+}
+
+func joinFields(list any, nameField []string) (string, bool, error) {
+	fieldName := "Name"
+	if len(nameField) != 0 {
+		fieldName = nameField[0]
+	}
+
+	items, err := toSlice(list)
+	if err != nil {
+		return "", false, err
+	}
+
+	named := false
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		name, err := getFieldValue(item, fieldName)
+		if err != nil {
+			return "", false, err
+		}
+
+		typeName, err := getFieldValue(item, "TypeName")
+		if err != nil {
+			return "", false, err
+		}
+
+		if name == "" {
+			res = append(res, typeName)
+		} else {
+			named = true
+			res = append(res, name+" "+typeName)
+		}
+	}
+
+	return strings.Join(res, ", "), named, nil
+}
+
+func toSlice(list any) ([]reflect.Value, error) {
+	val := reflect.ValueOf(list)
+	if !val.IsValid() {
+		return nil, nil
+	}
+
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return nil, fmt.Errorf("value is not list: %T", list)
+	}
+
+	res := make([]reflect.Value, 0, val.Len())
+	for i := range val.Len() {
+		res = append(res, val.Index(i))
+	}
+
+	return res, nil
+}
+
+// getFieldValue returns the value of the field or the method without arguments.
+func getFieldValue(item reflect.Value, name string) (string, error) {
+	if method := item.MethodByName(name); method.IsValid() && method.Type().NumIn() == 0 && method.Type().NumOut() == 1 {
+		return fmt.Sprint(method.Call(nil)[0].Interface()), nil
+	}
+
+	for item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface {
+		item = item.Elem()
+	}
+
+	if item.Kind() == reflect.Struct {
+		if field := item.FieldByName(name); field.IsValid() {
+			return fmt.Sprint(field.Interface()), nil
+		}
+	}
+
+	return "", fmt.Errorf("%s has no field or method %s", item.Type(), name)
+}
+
+// wrapComment wraps the text to the lines with the comment prefix "// ", the lines are not longer
+// than the width except the lines with the long words.
+func wrapComment(width int, text string) string {
+	lines := make([]string, 0)
+
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "//")
+			continue
+		}
+
+		line := "//"
+		for _, word := range words {
+			if line != "//" && len(line)+1+len(word) > width {
+				lines = append(lines, line)
+				line = "//"
+			}
+
+			line += " " + word
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,118 @@
+package templatepkg
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/stretchr/testify/require"
+)
+
+type testField struct {
+	Name     string
+	TypeName string
+	Type     astpkg.Type
+}
+
+func TestFuncMap(t *testing.T) {
+	t.Parallel()
+
+	execute := func(t *testing.T, text string, data any) (string, error) {
+		buf := bytes.NewBuffer(nil)
+		err := ExecuteTemplate(ExecuteTemplateParams{
+			Writer:       buf,
+			FS:           fstest.MapFS{"file.tmpl": {Data: []byte(text)}},
+			TemplateFile: "file.tmpl",
+			Data:         data,
+		})
+		return buf.String(), err
+	}
+
+	params := []testField{
+		{Name: "id", TypeName: "ID", Type: &astpkg.Ident{Name: "ID", Type: &astpkg.Ident{Name: "string"}}},
+		{Name: "args", TypeName: "...string", Type: &astpkg.EllipsisType{Type: &astpkg.Ident{Name: "string"}}},
+	}
+	results := []testField{
+		{Name: "", TypeName: "*Item", Type: &astpkg.StarExpr{Type: &astpkg.Ident{Name: "Item", Type: &astpkg.StructType{}}}},
+		{Name: "", TypeName: "error", Type: &astpkg.Ident{Name: "error"}},
+	}
+
+	for name, tc := range map[string]struct {
+		Text string
+		Data any
+		Want string
+	}{
+		"join params": {
+			Text: `{{ joinParams . }}`,
+			Data: params,
+			Want: "id ID, args ...string",
+		},
+		"join results": {
+			Text: `{{ joinResults . }}|{{ joinResults (slice . 1) }}|{{ joinResults (slice . 0 0) }}`,
+			Data: results,
+			Want: "(*Item, error)|error|",
+		},
+		"join named results": {
+			Text: `{{ joinResults . }}`,
+			Data: params[:1],
+			Want: "(id ID)",
+		},
+		"pluck and join": {
+			Text: `{{ pluck "Name" . | join ", " }}`,
+			Data: params,
+			Want: "id, args",
+		},
+		"cases": {
+			Text: `{{ snakeCase . }} {{ camelCase . }} {{ kebabCase . }} {{ lowerFirst . }} {{ upperFirst "user" }}`,
+			Data: "UserName",
+			Want: "user_name userName user-name userName User",
+		},
+		"camel case from snake case": {
+			Text: `{{ camelCase . }}`,
+			Data: "get_user_name",
+			Want: "getUserName",
+		},
+		"plural": {
+			Text: `{{ plural "Item" }} {{ plural "Box" }} {{ plural "Entry" }} {{ plural "Key" }} {{ plural "Match" }}`,
+			Want: "Items Boxes Entries Keys Matches",
+		},
+		"zero values": {
+			Text: `{{ range . }}{{ zeroValue .Type }};{{ end }}`,
+			Data: append(params, results...),
+			Want: `"";nil;nil;nil;`,
+		},
+		"kinds": {
+			Text: `{{ range . }}{{ typeKind .Type }} {{ isPointer .Type }} {{ isError .Type }} {{ isNillable .Type }};{{ end }}`,
+			Data: append(params, results...),
+			Want: "basic false false false;slice false false true;pointer true false true;basic false true true;",
+		},
+		"array": {
+			Text: `{{ range . }}{{ zeroValue .Type }} {{ typeKind .Type }} {{ isNillable .Type }};{{ end }}`,
+			Data: []testField{
+				{Name: "", TypeName: "[16]byte", Type: &astpkg.ArrayType{Len: "16", Type: &astpkg.Ident{Name: "byte"}}},
+			},
+			Want: "*new([16]byte) array false;",
+		},
+		"wrap comment": {
+			Text: `{{ wrapComment 20 . }}`,
+			Data: "Get returns the item by the identifier.\n\nDeprecated: use Find.",
+			Want: "// Get returns the\n// item by the\n// identifier.\n//\n// Deprecated: use\n// Find.",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := execute(t, tc.Text, tc.Data)
+			require.NoError(t, err)
+			require.Equal(t, tc.Want, res)
+		})
+	}
+
+	t.Run("unknown field", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := execute(t, `{{ pluck "Unknown" . }}`, params)
+		require.ErrorContains(t, err, "templatepkg.testField has no field or method Unknown")
+	})
+}
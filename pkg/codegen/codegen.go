@@ -0,0 +1,234 @@
+// Package codegen is the public API of the generators for the build tools: the packages
+// are loaded, the specifications are built and the code is generated without the command
+// line, the generated code is returned instead of writing the files.
+//
+// The parameters and the functions follow the semantic versioning of the module. The specifications
+// and the model of the packages are the aliases of the internal types: only the data of the templates
+// (docs/templates.md) is stable, the other fields and methods may change in the minor versions.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/khevse/codegen/internal/command/interface_creator"
+	"github.com/khevse/codegen/internal/command/object_test_wrapper"
+	"github.com/khevse/codegen/internal/pkg/astpkg"
+	"github.com/khevse/codegen/internal/pkg/filterpkg"
+	"github.com/khevse/codegen/internal/pkg/pluginpkg"
+)
+
+// The model of the parsed packages.
+type (
+	Package       = astpkg.Package
+	TypeDecl      = astpkg.TypeDecl
+	TypeDeclList  = astpkg.TypeDeclList
+	FuncDecl      = astpkg.FuncDecl
+	FuncDeclList  = astpkg.FuncDeclList
+	Field         = astpkg.Field
+	Type          = astpkg.Type
+	TypeKind      = astpkg.TypeKind
+	Import        = astpkg.Import
+	ImportList    = astpkg.ImportList
+	Directive     = astpkg.Directive
+	DirectiveList = astpkg.DirectiveList
+	PackageSchema = astpkg.PackageSchema
+)
+
+// The specifications of the generators: the data of the templates and the file paths.
+type (
+	InterfaceSpec = interface_creator.Spec
+	AssertSpec    = interface_creator.AssertSpec
+	WrapperSpec   = object_test_wrapper.Spec
+)
+
+// PackageCache shares the loaded packages between the generators.
+type PackageCache struct {
+	cache *astpkg.PackageCache
+}
+
+func (c *PackageCache) get() *astpkg.PackageCache {
+	if c == nil {
+		return nil
+	}
+
+	return c.cache
+}
+
+// MethodFilterParams are the include and exclude rules of the methods.
+// The name rule is <MethodName> for the methods of all types or <TypeName>.<MethodName>,
+// the regular expression matches <TypeName>.<MethodName>.
+type MethodFilterParams struct {
+	Include      []string
+	Exclude      []string
+	IncludeRegex []string
+	ExcludeRegex []string
+}
+
+func (p MethodFilterParams) convert() filterpkg.Params {
+	return filterpkg.Params{
+		Include:      p.Include,
+		Exclude:      p.Exclude,
+		IncludeRegex: p.IncludeRegex,
+		ExcludeRegex: p.ExcludeRegex,
+	}
+}
+
+// InterfaceParams are the parameters of the interfaces generation.
+type InterfaceParams struct {
+	// Types are the source types: <package>.<TypeName> or <package>.<TypeName>=<InterfaceName>
+	Types []string
+	// Funcs are the packages with the exported functions: <package>=<InterfaceName>
+	Funcs []string
+	// FuncPrefix is the prefix of the names of the collected functions, optional
+	FuncPrefix string
+	// FuncRegex is the regular expression matching the names of the collected functions, optional
+	FuncRegex string
+	// TargetDir is the dir of the generated file
+	TargetDir string
+	// FileSuffix is the suffix of the generated file name: interfaces<suffix>.go
+	FileSuffix string
+	// Filter are the include and exclude rules of the methods, optional
+	Filter MethodFilterParams
+	// Promoted includes the methods promoted from the embedded fields
+	Promoted bool
+	// MethodSet is the method set of the types: value, pointer or pointer-only, the assertion is generated for the selected set, optional
+	MethodSet string
+	// Assert generates the assertions of the types to the source packages, the files of InterfaceSpec.Asserts
+	Assert bool
+	// Template is the path of the custom template file, optional
+	Template string
+	// Cache is the cache of the parsed packages, optional
+	Cache *PackageCache
+}
+
+// WrapperParams are the parameters of the wrapper generation.
+type WrapperParams struct {
+	// InterfaceType is the interface of the wrapped object: <package>.<InterfaceName> or <package>.<InterfaceName>=<WrapperName>
+	InterfaceType string
+	// TargetDir is the dir of the generated file
+	TargetDir string
+	// FileName is the name of the generated file, wrapper.go by default
+	FileName string
+	// MockPackage is the package of the mocks
+	MockPackage string
+	// MockBackend is the mocks generator: minimock (default), gomock, mockery, codegen
+	MockBackend string
+	// Filter are the include and exclude rules of the methods, the excluded methods call the base object, optional
+	Filter MethodFilterParams
+	// Template is the path of the custom template file, optional
+	Template string
+	// Cache is the cache of the parsed packages, optional
+	Cache *PackageCache
+}
+
+// The protocol of the plugins: the request is read from stdin, the response is written to stdout as JSON.
+type (
+	PluginRequest  = pluginpkg.Request
+	PluginResponse = pluginpkg.Response
+	PluginFile     = pluginpkg.File
+)
+
+// PluginProtocolVersion is the version of the plugins protocol.
+const PluginProtocolVersion = pluginpkg.Version
+
+// File is the generated file.
+type File struct {
+	Path string
+	Data []byte
+}
+
+// NewPackageCache returns the cache sharing the loaded packages between the generators.
+func NewPackageCache() *PackageCache {
+	return &PackageCache{cache: astpkg.NewPackageCache()}
+}
+
+// LoadPackage parses the package by the import path.
+func LoadPackage(pkgPath string) (*Package, error) {
+	return astpkg.ParsePackage(pkgPath)
+}
+
+// NewPackageSchema returns the serializable representation of the package.
+func NewPackageSchema(pkg *Package) PackageSchema {
+	return astpkg.NewPackageSchema(pkg)
+}
+
+// GetTypeKind returns the kind of the underlying type.
+func GetTypeKind(t Type) TypeKind {
+	return astpkg.GetTypeKind(t)
+}
+
+// NewInterfaceSpec builds the specification of the interfaces of the types.
+func NewInterfaceSpec(params InterfaceParams) (*InterfaceSpec, error) {
+	return interface_creator.NewSpec(interface_creator.Params{
+		Types:      params.Types,
+		Funcs:      params.Funcs,
+		FuncPrefix: params.FuncPrefix,
+		FuncRegex:  params.FuncRegex,
+		TargetDir:  params.TargetDir,
+		FileSuffix: params.FileSuffix,
+		Filter:     params.Filter.convert(),
+		Promoted:   params.Promoted,
+		MethodSet:  params.MethodSet,
+		Assert:     params.Assert,
+		Template:   params.Template,
+		Cache:      params.Cache.get(),
+	})
+}
+
+// GenerateInterfaces generates the interfaces of the types. The first file is the interfaces file,
+// it is followed by the files of the assertions (InterfaceParams.Assert).
+func GenerateInterfaces(params InterfaceParams) ([]File, error) {
+	spec, err := NewInterfaceSpec(params)
+	if err != nil {
+		return nil, fmt.Errorf("new interface specification: %w", err)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := spec.Generate(buf); err != nil {
+		return nil, fmt.Errorf("generate interfaces: %w", err)
+	}
+
+	files := make([]File, 0, 1+len(spec.Asserts))
+	files = append(files, File{Path: spec.FilePath, Data: buf.Bytes()})
+
+	for _, item := range spec.Asserts {
+		buf := bytes.NewBuffer(nil)
+		if err := item.Generate(buf); err != nil {
+			return nil, fmt.Errorf("generate assertions(%s): %w", item.FilePath, err)
+		}
+
+		files = append(files, File{Path: item.FilePath, Data: buf.Bytes()})
+	}
+
+	return files, nil
+}
+
+// NewWrapperSpec builds the specification of the test wrapper of the interface.
+func NewWrapperSpec(params WrapperParams) (*WrapperSpec, error) {
+	return object_test_wrapper.NewSpec(object_test_wrapper.Params{
+		InterfaceType: params.InterfaceType,
+		TargetDir:     params.TargetDir,
+		FileName:      params.FileName,
+		MockPackage:   params.MockPackage,
+		MockBackend:   params.MockBackend,
+		Filter:        params.Filter.convert(),
+		Template:      params.Template,
+		Cache:         params.Cache.get(),
+	})
+}
+
+// GenerateObjectTestWrapper generates the test wrapper of the interface.
+func GenerateObjectTestWrapper(params WrapperParams) (File, error) {
+	spec, err := NewWrapperSpec(params)
+	if err != nil {
+		return File{}, fmt.Errorf("new wrapper specification: %w", err)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := spec.Generate(buf); err != nil {
+		return File{}, fmt.Errorf("generate wrapper: %w", err)
+	}
+
+	return File{Path: spec.FilePath, Data: buf.Bytes()}, nil
+}
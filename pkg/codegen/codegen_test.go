@@ -0,0 +1,172 @@
+package codegen
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+const testPackage = "github.com/khevse/codegen/tests/annotatedpkg"
+
+func TestLoadPackage(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := LoadPackage(testPackage)
+	require.NoError(t, err)
+
+	typeDecl, ok := pkg.TypeDeclList.GetByName("Store")
+	require.True(t, ok)
+	require.Equal(t, TypeKind("struct"), GetTypeKind(typeDecl.Type))
+
+	schema := NewPackageSchema(pkg)
+	require.Equal(t, testPackage, schema.Path)
+}
+
+func TestGenerateInterfaces(t *testing.T) {
+	t.Parallel()
+
+	// the code is returned, the file is not written
+	targetDir, err := filepath.Abs("./")
+	require.NoError(t, err)
+
+	files, err := GenerateInterfaces(InterfaceParams{
+		Types:     []string{testPackage + ".Store=IStore"},
+		TargetDir: targetDir,
+		Cache:     NewPackageCache(),
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	file := files[0]
+	require.Equal(t, filepath.Join(targetDir, "interfaces.go"), file.Path)
+	require.NoFileExists(t, file.Path)
+
+	want := `// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package codegen
+
+import (
+	context "context"
+)
+
+/* IStore interface for type Store: Store storage of the values */
+type IStore interface {
+	/* Get returns the value by the key */
+	Get(ctx context.Context, key string) (_ string, _ bool)
+	Set(ctx context.Context, key string, value string)
+}
+`
+	require.Empty(t, cmp.Diff(want, string(file.Data)))
+
+	// the assertions are returned after the interfaces file
+	files, err = GenerateInterfaces(InterfaceParams{
+		Types:     []string{testPackage + ".Store=IStore"},
+		TargetDir: targetDir,
+		Assert:    true,
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Empty(t, cmp.Diff(want, string(files[0].Data)))
+
+	sourceDir, err := filepath.Abs("../../tests/annotatedpkg")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(sourceDir, "codegen_interfaces_assert.go"), files[1].Path)
+	require.NoFileExists(t, files[1].Path)
+	require.Empty(t, cmp.Diff(`// Code generated by http://github.com/khevse/codegen(version:; commit:; build:). DO NOT EDIT.
+
+package annotatedpkg
+
+import (
+	codegen "github.com/khevse/codegen/pkg/codegen"
+)
+
+var _ codegen.IStore = (*Store)(nil)
+`, string(files[1].Data)))
+
+	_, err = GenerateInterfaces(InterfaceParams{
+		Types:     []string{testPackage + ".Unknown"},
+		TargetDir: targetDir,
+	})
+	require.EqualError(t, err, "new interface specification: prepare objects specifications: not found type: Unknown")
+}
+
+func TestNewInterfaceSpec(t *testing.T) {
+	t.Parallel()
+
+	spec, err := NewInterfaceSpec(InterfaceParams{
+		Types:      []string{testPackage + ".Store", testPackage + ".Counter=ICounter"},
+		TargetDir:  "./",
+		FileSuffix: "_generated",
+	})
+	require.NoError(t, err)
+
+	dir, err := filepath.Abs("./")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "interfaces_generated.go"), spec.FilePath)
+	require.Equal(t, "codegen", spec.Package)
+
+	names := make([]string, 0, len(spec.Interfaces))
+	for _, item := range spec.Interfaces {
+		names = append(names, item.Name)
+	}
+	require.ElementsMatch(t, []string{"Store", "ICounter"}, names)
+}
+
+func TestNewInterfaceSpecFilter(t *testing.T) {
+	t.Parallel()
+
+	spec, err := NewInterfaceSpec(InterfaceParams{
+		Types:     []string{testPackage + ".Store=IStore"},
+		TargetDir: "./",
+		Filter:    MethodFilterParams{Exclude: []string{"Store.Set"}},
+		Cache:     NewPackageCache(),
+	})
+	require.NoError(t, err)
+	require.Len(t, spec.Interfaces, 1)
+
+	names := make([]string, 0, len(spec.Interfaces[0].Methods))
+	for _, item := range spec.Interfaces[0].Methods {
+		names = append(names, item.Name)
+	}
+	require.Equal(t, []string{"Get"}, names)
+
+	_, err = NewInterfaceSpec(InterfaceParams{
+		Types:     []string{testPackage + ".Store=IStore"},
+		TargetDir: "./",
+		Filter:    MethodFilterParams{Include: []string{"Store.Delete"}},
+	})
+	require.EqualError(t, err, "include rule matches nothing: Store.Delete")
+}
+
+func TestGenerateObjectTestWrapper(t *testing.T) {
+	t.Parallel()
+
+	targetDir := filepath.Join("..", "..", "tests", "annotatedpkg", "wrappers")
+
+	file, err := GenerateObjectTestWrapper(WrapperParams{
+		InterfaceType: testPackage + ".Factory=FactoryWrapper",
+		TargetDir:     targetDir,
+		MockPackage:   testPackage + "/mocks",
+		MockBackend:   "codegen",
+	})
+	require.NoError(t, err)
+
+	wantPath, err := filepath.Abs(filepath.Join(targetDir, "wrapper.go"))
+	require.NoError(t, err)
+	require.Equal(t, wantPath, file.Path)
+
+	data := string(file.Data)
+	require.True(t, strings.HasPrefix(data, "// Code generated by http://github.com/khevse/codegen"))
+	require.Contains(t, data, "type FactoryWrapper struct {\n\tmocks FactoryWrapperMocks\n\tbase  annotatedpkg.Factory\n}")
+	require.Contains(t, data, "Reader: mocks.NewReaderMock(t),")
+
+	_, err = GenerateObjectTestWrapper(WrapperParams{
+		InterfaceType: testPackage + ".Factory",
+		TargetDir:     targetDir,
+		MockPackage:   testPackage + "/mocks",
+		MockBackend:   "unknown",
+	})
+	require.ErrorContains(t, err, "new wrapper specification: get mock backend")
+}
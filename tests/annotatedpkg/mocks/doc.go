@@ -0,0 +1,2 @@
+// Package mocks contains the mocks of the annotatedpkg interfaces.
+package mocks
@@ -0,0 +1,43 @@
+package annotatedpkg
+
+import "context"
+
+// Store storage of the values
+//
+//codegen:interface name=IStore
+type Store struct{}
+
+// Get returns the value by the key
+func (s *Store) Get(ctx context.Context, key string) (string, bool) {
+	return "", false
+}
+
+func (s *Store) Set(ctx context.Context, key, value string) {}
+
+// Counter counter of the values
+//
+//codegen:interface
+type Counter struct{}
+
+func (c Counter) Count() int {
+	return 0
+}
+
+// Reader reader of the values
+type Reader interface {
+	Read(ctx context.Context, key string) (string, error)
+}
+
+// Factory factory of the readers
+//
+//codegen:wrapper mock=./mocks dir=./wrappers backend=codegen
+type Factory interface {
+	Reader() Reader
+}
+
+// NotAnnotated type without directives
+type NotAnnotated struct{}
+
+func (n NotAnnotated) Name() string {
+	return ""
+}
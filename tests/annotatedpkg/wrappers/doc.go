@@ -0,0 +1,2 @@
+// Package wrappers contains the test wrappers of the annotatedpkg interfaces.
+package wrappers
@@ -0,0 +1,12 @@
+package decoratorpkg
+
+import (
+	"context"
+	"time"
+)
+
+// IAudit audit of the service, the parameters clash with the names of the generated code
+type IAudit interface {
+	// Record saves the event
+	Record(ctx context.Context, d time.Duration, span string) error
+}
@@ -0,0 +1,25 @@
+package decoratorpkg
+
+import (
+	"context"
+)
+
+// User user of the service
+type User struct {
+	ID   int
+	Name string
+}
+
+// IService service of the users
+type IService interface {
+	// Login returns the token of the user
+	Login(ctx context.Context, user, password string) (token string, err error)
+	// Find returns the users by the identifiers
+	Find(ctx context.Context, ids ...int) ([]User, error)
+	// Ping checks the service
+	Ping() error
+	// Name returns the name of the service
+	Name() string
+	// Close releases the resources
+	Close()
+}
@@ -0,0 +1,52 @@
+package filteredpkg
+
+import "errors"
+
+var _ IAccount = (*Account)(nil)
+
+// IAccount .
+type IAccount interface {
+	// ID comment
+	ID() string
+	// Owner comment
+	Owner() IOwner
+	// Refresh comment
+	//
+	//codegen:ignore
+	Refresh() error
+}
+
+// IOwner .
+type IOwner interface {
+	Name() string
+}
+
+// Account comment
+type Account struct {
+	id      string
+	owner   IOwner
+	balance int
+}
+
+// ID comment
+func (a *Account) ID() string { return a.id }
+
+// Owner comment
+func (a *Account) Owner() IOwner { return a.owner }
+
+// Balance comment
+func (a *Account) Balance() int { return a.balance }
+
+// SetBalance comment
+func (a *Account) SetBalance(val int) { a.balance = val }
+
+// Refresh comment
+//
+//codegen:ignore
+func (a *Account) Refresh() error {
+	if a.id == "" {
+		return errors.New("empty id")
+	}
+
+	return nil
+}
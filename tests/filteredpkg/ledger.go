@@ -0,0 +1,11 @@
+package filteredpkg
+
+import "cmp"
+
+// Ledger comment
+type Ledger[K cmp.Ordered, V any] struct {
+	items map[K]V
+}
+
+// Get comment
+func (l *Ledger[K, V]) Get(key K) V { return l.items[key] }
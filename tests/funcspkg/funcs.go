@@ -0,0 +1,50 @@
+package funcspkg
+
+import (
+	"context"
+	"io"
+)
+
+// User user of the storage
+type User struct {
+	ID   int
+	Name string
+}
+
+// String returns the name of the user, the methods are not collected
+func (u *User) String() string {
+	return u.Name
+}
+
+// FetchUser returns the user by the identifier
+func FetchUser(ctx context.Context, id int) (*User, error) {
+	return &User{ID: id}, nil
+}
+
+// FetchUsers returns the users by the identifiers
+func FetchUsers(ctx context.Context, ids ...int) ([]*User, error) {
+	return nil, nil
+}
+
+func StoreUser(context.Context, *User) error {
+	return nil
+}
+
+// Export writes the users
+func Export(w io.Writer, users []*User) (n int, err error) {
+	return 0, nil
+}
+
+// Lookup returns the value by the key, the arguments clash with the package name and the generated names
+func Lookup(funcspkg string, _ int, p1 bool) (context string, err error) {
+	return funcspkg, nil
+}
+
+// Map is generic, the generic functions are not collected
+func Map[T any](items []T, fn func(T) T) []T {
+	return items
+}
+
+func validate(user *User) error {
+	return nil
+}
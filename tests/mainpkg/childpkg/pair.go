@@ -0,0 +1,7 @@
+package childpkg
+
+// Pair comment
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
@@ -4,3 +4,8 @@ package childpkg
 type Struct struct {
 	FieldString string
 }
+
+// GetFieldString comment
+func (s Struct) GetFieldString() string {
+	return s.FieldString
+}
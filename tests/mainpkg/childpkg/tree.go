@@ -0,0 +1,7 @@
+package childpkg
+
+// Tree comment
+type Tree interface {
+	Parent() Tree
+	Children() []Tree
+}
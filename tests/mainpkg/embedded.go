@@ -0,0 +1,16 @@
+package mainpkg
+
+import (
+	"io"
+
+	"github.com/khevse/codegen/tests/mainpkg/childpkg"
+)
+
+// IEmbedded comment .
+type IEmbedded interface {
+	IObject1
+	childpkg.Interface
+	io.Closer
+	// Name comment
+	Name() string
+}
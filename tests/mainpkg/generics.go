@@ -0,0 +1,35 @@
+package mainpkg
+
+import "github.com/khevse/codegen/tests/mainpkg/childpkg"
+
+// Number comment
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+// Cache comment
+type Cache[K comparable, V any] struct {
+	items map[K]V
+}
+
+// Get comment
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	val, ok := c.items[key]
+	return val, ok
+}
+
+func (c *Cache[K, V]) Set(key K, val V) {
+	c.items[key] = val
+}
+
+func (c Cache[Key, Value]) Pair(key Key) childpkg.Pair[Key, Value] {
+	return childpkg.Pair[Key, Value]{Key: key, Value: c.items[key]}
+}
+
+// IRepository comment
+type IRepository[T any, ID Number] interface {
+	// Get comment
+	Get(id ID) (T, error)
+	// Object comment
+	Object(id ID) IObject1
+}
@@ -0,0 +1,28 @@
+package mainpkg
+
+import (
+	"sync"
+
+	"github.com/khevse/codegen/tests/mainpkg/childpkg"
+)
+
+// BaseService comment
+type BaseService struct {
+	sync.Mutex
+}
+
+// Name comment
+func (s *BaseService) Name() string { return "base" }
+
+// Close comment
+func (s *BaseService) Close() error { return nil }
+
+// Service comment
+type Service struct {
+	*BaseService
+	childpkg.Struct
+	IObject1
+}
+
+// Name comment
+func (s Service) Name() string { return "service" }
@@ -0,0 +1,17 @@
+package mainpkg
+
+import "github.com/khevse/codegen/tests/mainpkg/childpkg"
+
+// ChildInterface comment
+type ChildInterface = childpkg.Interface
+
+// ID comment
+type ID string
+
+// IResolver comment
+type IResolver interface {
+	Interface() ChildInterface
+	Tree() childpkg.Tree
+	ID() ID
+	Cache() *Cache[ID, childpkg.Struct]
+}
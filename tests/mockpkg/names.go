@@ -0,0 +1,13 @@
+package mockpkg
+
+// INames has the arguments with the names clashing with the generated code.
+type INames interface {
+	Same(a int, A string) int
+	Reserved(mm, mmParams, mmResults string, fmt, reflect, slices int) (e error)
+	Indexed(p1 int, _ string) (r0 int, _ error)
+}
+
+// IStore is the generic interface, the arguments clash with the type parameters.
+type IStore[K comparable, V any] interface {
+	Get(k K) (v V, ok bool)
+}